@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// --- Reaction Network Analysis ---
+
+// Bounds on the cycle search so large random networks can't blow up.
+const (
+	MaxCycleLength = 8    // Longest cycle (in reactions) that is searched for
+	MaxCycles      = 1000 // Stop after this many cycles have been found
+)
+
+// catalysisGraph returns, for each reaction, the reactions catalyzed by one of its products.
+func (p *Pond) catalysisGraph() [][]int {
+	catalyzedBy := make(map[string][]int) // species -> reactions it catalyzes
+	for j, r := range p.Reactions {
+		for _, c := range r.AllCatalysts() {
+			catalyzedBy[c] = append(catalyzedBy[c], j)
+		}
+	}
+
+	graph := make([][]int, len(p.Reactions))
+	for i, r := range p.Reactions {
+		seen := make(map[int]bool)
+		for _, product := range r.AllProducts() {
+			for _, j := range catalyzedBy[product] {
+				if !seen[j] {
+					seen[j] = true
+					graph[i] = append(graph[i], j)
+				}
+			}
+		}
+	}
+	return graph
+}
+
+// FindCatalyticCycles returns the closed cycles of reaction indices in which
+// each reaction's product catalyzes the next one, the last wrapping back to
+// the first. A self-catalyzing reaction such as R3 is a cycle of length one.
+// Each cycle is listed once, starting from its lowest index. The search is
+// bounded by MaxCycleLength and MaxCycles.
+func (p *Pond) FindCatalyticCycles() [][]int {
+	graph := p.catalysisGraph()
+	cycles := [][]int{}
+
+	var path []int
+	onPath := make([]bool, len(graph))
+	var visit func(start, node int)
+	visit = func(start, node int) {
+		path = append(path, node)
+		onPath[node] = true
+		for _, next := range graph[node] {
+			if len(cycles) >= MaxCycles {
+				break
+			}
+			if next == start {
+				cycles = append(cycles, append([]int(nil), path...))
+				continue
+			}
+			// Only extend through higher indices so each cycle is found from its lowest member
+			if next > start && !onPath[next] && len(path) < MaxCycleLength {
+				visit(start, next)
+			}
+		}
+		onPath[node] = false
+		path = path[:len(path)-1]
+	}
+
+	for start := range graph {
+		if len(cycles) >= MaxCycles {
+			break
+		}
+		visit(start, start)
+	}
+	return cycles
+}
+
+// Reachable returns the species that can ever be present, starting from those
+// with a positive count and repeatedly firing every reaction whose reactants
+// and catalysts are all reachable.
+func (p *Pond) Reachable() map[string]bool {
+	reachable := make(map[string]bool)
+	for name, count := range p.Molecules {
+		if count > 0 {
+			reachable[name] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, r := range p.Reactions {
+			if !allIn(reachable, r.Reactants) || !allIn(reachable, r.AllCatalysts()) {
+				continue
+			}
+			for _, product := range r.AllProducts() {
+				if !reachable[product] {
+					reachable[product] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return reachable
+}
+
+// PathwayLength returns the fewest reactions leading from species from to
+// species to, following each reaction from its reactants to its products
+// (catalysts are not steps, and the other reactants a step needs are
+// assumed available). It is 0 when from and to are the same and -1 when to
+// cannot be reached.
+func (p *Pond) PathwayLength(from, to string) int {
+	if from == to {
+		return 0
+	}
+	dist := map[string]int{from: 0}
+	queue := []string{from}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, r := range p.Reactions {
+			if countOf(r.Reactants, name) == 0 {
+				continue
+			}
+			for _, product := range r.AllProducts() {
+				if _, seen := dist[product]; seen {
+					continue
+				}
+				if product == to {
+					return dist[name] + 1
+				}
+				dist[product] = dist[name] + 1
+				queue = append(queue, product)
+			}
+		}
+	}
+	return -1
+}
+
+// TraceDependencies returns the reactions needed to synthesize species, each
+// after the reactions making its reactants and catalysts, so the list reads
+// as a recipe from food. It walks back from species through every reaction
+// producing it; a reaction is left out when one of its reactants can only
+// be had by first making the species being traced (such as E -> A when
+// tracing the E that A is needed for), which leaves that reactant as food.
+// A catalyst on the way may be the traced species itself, as in
+// autocatalysis. Species no usable reaction produces count as food.
+func (p *Pond) TraceDependencies(species string) []Reaction {
+	const (
+		unvisited = iota
+		tracing
+		traced
+	)
+	state := map[string]int{}
+	added := make([]bool, len(p.Reactions))
+	var order []Reaction
+
+	var trace func(name string)
+	trace = func(name string) {
+		state[name] = tracing
+		for i, r := range p.Reactions {
+			if added[i] || countOf(r.AllProducts(), name) == 0 {
+				continue
+			}
+			circular := false
+			for _, reactant := range r.Reactants {
+				if state[reactant] == tracing {
+					circular = true
+				}
+			}
+			if circular {
+				continue
+			}
+			for _, input := range append(append([]string(nil), r.Reactants...), r.AllCatalysts()...) {
+				if state[input] == unvisited {
+					trace(input)
+				}
+			}
+			if !added[i] {
+				added[i] = true
+				order = append(order, r)
+			}
+		}
+		state[name] = traced
+	}
+	trace(species)
+	return order
+}
+
+// allIn reports whether every name is in the set.
+func allIn(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if !set[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// DeadReactions returns the indices of reactions that have never fired.
+func (p *Pond) DeadReactions() []int {
+	dead := []int{}
+	for i := range p.Reactions {
+		if i >= len(p.FireCounts) || p.FireCounts[i] == 0 {
+			dead = append(dead, i)
+		}
+	}
+	return dead
+}
+
+// ReactionCoverage returns the percentage of n reactions that fired at least
+// once according to fireCounts (indexed by reaction; missing entries count as
+// never fired). A network without reactions is fully covered.
+func ReactionCoverage(fireCounts []int, n int) float64 {
+	if n == 0 {
+		return 100
+	}
+	fired := 0
+	for i := 0; i < n && i < len(fireCounts); i++ {
+		if fireCounts[i] > 0 {
+			fired++
+		}
+	}
+	return 100 * float64(fired) / float64(n)
+}
+
+// Coverage returns the percentage of reactions that have fired so far.
+func (p *Pond) Coverage() float64 {
+	return ReactionCoverage(p.FireCounts, len(p.Reactions))
+}
+
+// Diversity returns the Shannon diversity (entropy in nats) of the counts:
+// 0 when all molecules belong to one species (or there are none), ln(n)
+// when n species are equally abundant.
+func (p *Pond) Diversity() float64 {
+	total := float64(p.TotalPopulation())
+	if total <= 0 {
+		return 0
+	}
+	h := 0.0
+	for _, count := range p.Molecules {
+		if count > 0 {
+			share := float64(count) / total
+			h -= share * math.Log(share)
+		}
+	}
+	return h
+}
+
+// reactionLabels formats reaction indices as "R1, R3", or "none" when empty.
+func reactionLabels(indices []int) string {
+	if len(indices) == 0 {
+		return "none"
+	}
+	labels := make([]string, len(indices))
+	for i, idx := range indices {
+		labels[i] = fmt.Sprintf("R%d", idx+1)
+	}
+	return strings.Join(labels, ", ")
+}
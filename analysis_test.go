@@ -0,0 +1,150 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindCatalyticCyclesSingleCycle(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10},
+		Reaction{Reactants: []string{"A"}, Product: "X", Catalysts: []string{"Z"}},
+		Reaction{Reactants: []string{"A"}, Product: "Y", Catalysts: []string{"X"}},
+		Reaction{Reactants: []string{"A"}, Product: "Z", Catalysts: []string{"Y"}},
+		Reaction{Reactants: []string{"A"}, Product: "Q", Catalysts: []string{"Y"}}, // Leaves the cycle
+	)
+	if got, want := p.FindCatalyticCycles(), [][]int{{0, 1, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindCatalyticCycles() = %v, want %v", got, want)
+	}
+}
+
+func TestFindCatalyticCyclesAcyclic(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10},
+		Reaction{Reactants: []string{"A"}, Product: "X"},
+		Reaction{Reactants: []string{"A"}, Product: "Y", Catalysts: []string{"X"}},
+		Reaction{Reactants: []string{"A"}, Product: "Z", Catalysts: []string{"Y"}},
+	)
+	if got := p.FindCatalyticCycles(); len(got) != 0 {
+		t.Errorf("FindCatalyticCycles() = %v, want none", got)
+	}
+}
+
+func TestFindCatalyticCyclesSelfCatalysis(t *testing.T) {
+	// R3 of the default chemistry (D + A -> E, catalyzed by E) catalyzes itself
+	if got, want := NewPondWithSeed(1).FindCatalyticCycles(), [][]int{{2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindCatalyticCycles() = %v, want %v", got, want)
+	}
+}
+
+func TestDeadReactions(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 100, "Z": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"Z"}, Product: "C"}, // Z is never present
+	)
+	for i := 0; i < 100; i++ {
+		p.Step()
+	}
+	if got, want := p.DeadReactions(), []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DeadReactions() = %v, want %v", got, want)
+	}
+}
+
+func TestReactionCoverage(t *testing.T) {
+	tests := []struct {
+		name       string
+		fireCounts []int
+		n          int
+		want       float64
+	}{
+		{"all fired", []int{3, 1, 7}, 3, 100},
+		{"some never fired", []int{5, 0, 2, 0}, 4, 50},
+		{"none fired", []int{0, 0, 0}, 3, 0},
+		{"missing entries", []int{1}, 4, 25},
+		{"extra entries ignored", []int{1, 0, 9}, 2, 50},
+		{"no reactions", nil, 0, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReactionCoverage(tt.fireCounts, tt.n); got != tt.want {
+				t.Errorf("ReactionCoverage(%v, %d) = %v, want %v", tt.fireCounts, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPondCoverage(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 100, "Z": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"Z"}, Product: "C"},
+	)
+	p.Run(100)
+	if got := p.Coverage(); got != 50 {
+		t.Errorf("Coverage() = %v, want 50", got)
+	}
+}
+
+func TestPathwayLength(t *testing.T) {
+	p := NewPondWithSeed(1)
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{"A", "E", 1}, // D + A -> E
+		{"B", "E", 2}, // A + B -> D, then D + C -> E
+		{"E", "D", 2}, // E -> A, then A + B -> D
+		{"A", "A", 0},
+		{"E", "B", -1}, // Nothing makes B
+		{"A", "Z", -1},
+	}
+	for _, tt := range tests {
+		if got := p.PathwayLength(tt.from, tt.to); got != tt.want {
+			t.Errorf("PathwayLength(%s, %s) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestPathwayLengthFollowsByProducts(t *testing.T) {
+	p := testPond(1, map[string]int{},
+		Reaction{Reactants: []string{"F"}, Product: "G", ByProducts: []string{"H"}},
+		Reaction{Reactants: []string{"H"}, Product: "R", Catalysts: []string{"Q"}},
+		Reaction{Reactants: []string{"Q"}, Product: "F"},
+	)
+	if got := p.PathwayLength("F", "R"); got != 2 {
+		t.Errorf("PathwayLength(F, R) = %d, want 2 through the by-product H", got)
+	}
+	if got := p.PathwayLength("F", "Q"); got != -1 {
+		t.Errorf("PathwayLength(F, Q) = %d, want -1: a catalyst is not a product", got)
+	}
+}
+
+func TestTraceDependencies(t *testing.T) {
+	p := NewPondWithSeed(1)
+	got := p.TraceDependencies("E")
+
+	// R1 makes D, which both R2 and R3 need; R4 (E -> A) needs E itself
+	want := []Reaction{p.Reactions[0], p.Reactions[1], p.Reactions[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TraceDependencies(E) = %v, want R1, R2, R3", got)
+	}
+
+	// A + B -> D needs A, so D is left as food on the way back from A
+	if got, want := p.TraceDependencies("A"), []Reaction{p.Reactions[1], p.Reactions[3]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TraceDependencies(A) = %v, want R2, R4", got)
+	}
+	if got := p.TraceDependencies("Z"); len(got) != 0 {
+		t.Errorf("TraceDependencies(Z) = %v, want none", got)
+	}
+}
+
+func TestTraceDependenciesFollowsCatalysts(t *testing.T) {
+	p := testPond(1, map[string]int{},
+		Reaction{Reactants: []string{"H", "F"}, Product: "T", Catalysts: []string{"K"}},
+		Reaction{Reactants: []string{"G"}, Product: "K"},
+		Reaction{Reactants: []string{"F"}, Product: "G"},
+		Reaction{Reactants: []string{"X"}, Product: "Y"}, // Unrelated
+	)
+	got := p.TraceDependencies("T")
+	want := []Reaction{p.Reactions[2], p.Reactions[1], p.Reactions[0]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TraceDependencies(T) = %v, want F -> G, G -> K, H + F -> T", got)
+	}
+}
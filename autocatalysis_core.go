@@ -2,13 +2,21 @@ package main
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"log"
+	"math"
 	"math/rand"
+	"net/http"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font/basicfont"
 )
@@ -16,7 +24,13 @@ import (
 const (
 	ScreenWidth  = 800
 	ScreenHeight = 600
-	StepsPerTick = 100 // Speed up the simulation dramatically
+
+	// Defaults for the per-experiment parameters; a config file can override both.
+	DefaultStepsPerTick       = 100  // Speed up the simulation dramatically
+	DefaultEmergenceThreshold = 5000 // E count at which the CAS is considered dominant
+
+	DefaultBarDivisor = 5 // Molecules per pixel of a linear count bar
+	DefaultTimeStep   = 1 // Simulated seconds per tick
 )
 
 // --- SIMULATION CORE (Pond, Molecule, Reaction remain largely the same) ---
@@ -24,28 +38,320 @@ const (
 // A simplified Molecule struct.
 type Molecule struct {
 	Name string
+	Role MoleculeRole // Which sides of a generated reaction the molecule may appear on
 }
 
+// MoleculeRole constrains how random network generation may use a molecule.
+type MoleculeRole int
+
+const (
+	RoleAny   MoleculeRole = iota // May be consumed or produced
+	RoleFood                      // Only consumed (or catalyzing), never produced
+	RoleWaste                     // Only produced (or catalyzing), never consumed
+)
+
 // A Reaction defines how molecules interact.
-// If Catalyst is empty, it's a non-catalytic reaction.
-// If Product equals Catalyst, it has the potential to be autocatalytic.
+// If Catalysts is empty, it's a non-catalytic reaction; otherwise every listed
+// catalyst must be present for the reaction to fire.
+// If Product equals one of the Catalysts, it has the potential to be autocatalytic.
+// A species listed twice in Reactants or ByProducts takes part twice (2A -> ...).
 type Reaction struct {
-	Reactants []string
-	Product   string
-	Catalyst  string
+	Reactants  []string `json:"reactants"`
+	Product    string   `json:"product"`
+	ByProducts []string `json:"byProducts,omitempty"` // Further products released alongside Product
+	Catalysts  []string `json:"catalysts,omitempty"`
+
+	// Branches, when set, replace Product and ByProducts: each firing yields
+	// the products of one branch, chosen in proportion to the branch
+	// probabilities (normalized if they don't sum to 1).
+	Branches []Branch `json:"branches,omitempty"`
+
+	// Rate is the relative weight used when picking which reaction to attempt.
+	// Zero means the default rate of 1.
+	Rate float64 `json:"rate,omitempty"`
+
+	// Disabled reactions are never selected; toggled live from the keyboard.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// CatalystDelay, when positive, makes the catalysts stoichiometric: firing
+	// takes one unit of each catalyst out of the pond and returns it
+	// CatalystDelay steps later, limiting how fast the reaction can run.
+	CatalystDelay int `json:"catalystDelay,omitempty"`
+
+	// MinTotalPopulation, when positive, keeps the reaction dormant until the
+	// summed count of all molecules reaches it (quorum-sensing style switch).
+	MinTotalPopulation int `json:"minTotalPopulation,omitempty"`
+
+	// RateLaw, when set, replaces mass-action kinetics: its result is the
+	// reaction's propensity (see Propensity). It cannot be saved to a config.
+	RateLaw func(p *Pond, r Reaction) float64 `json:"-"`
+
+	// NeighborCatalyzed lets a grid cell use catalysts present in its
+	// neighbouring cells (see Grid), modelling surface chemistry. Outside a
+	// grid it has no effect. Such catalysts cannot be sequestered.
+	NeighborCatalyzed bool `json:"neighborCatalyzed,omitempty"`
+
+	// HalfLife, when positive, makes the reaction a first-order decay of its
+	// single reactant specified by half-life in ticks: instead of being
+	// selected by Step, it runs once per tick, each molecule decaying with
+	// probability DecayProbability(HalfLife) (see Pond.Decay).
+	HalfLife float64 `json:"halfLife,omitempty"`
+
+	// ProductInhibition, when positive, slows the reaction as its Product
+	// accumulates: its rate is divided by 1 + ProductInhibition * count(Product)
+	// (see Pond.InhibitedRate). It does not apply to rate laws or branches.
+	ProductInhibition float64 `json:"productInhibition,omitempty"`
+
+	// SuccessProbability, when in (0,1), makes the reaction fail at random:
+	// once selected and able to fire, it fires only with this probability and
+	// otherwise leaves the counts untouched (imperfect catalysis). Zero means
+	// it always succeeds.
+	SuccessProbability float64 `json:"successProbability,omitempty"`
+
+	// MinReactantRatio, when set, blocks the reaction unless one species is
+	// present in at least the given multiple of another (templated assembly).
+	MinReactantRatio *ReactantRatio `json:"minReactantRatio,omitempty"`
+
+	// ProductYield is how many units of Product one firing makes, e.g. a
+	// template producing 10 copies; zero means 1. By-products and branches
+	// are unaffected.
+	ProductYield int `json:"productYield,omitempty"`
+
+	// FromPool and ToPool place the reaction across molecule pools: its
+	// reactants and catalysts are taken from FromPool and its products
+	// released into ToPool (see Reaction.Pooled). Empty means the bulk pool.
+	FromPool string `json:"fromPool,omitempty"`
+	ToPool   string `json:"toPool,omitempty"`
+
+	// Description explains the reaction's role, e.g. for the tutorial overlay.
+	Description string `json:"description,omitempty"`
+
+	// Deprecated: Catalyst is the old single-catalyst field. It is still
+	// honoured alongside Catalysts so existing reaction tables keep working.
+	Catalyst string `json:"catalyst,omitempty"`
+}
+
+// AllCatalysts returns every catalyst the reaction requires, folding the
+// legacy single Catalyst field into the Catalysts list.
+func (r Reaction) AllCatalysts() []string {
+	if r.Catalyst == "" {
+		return r.Catalysts
+	}
+	for _, c := range r.Catalysts {
+		if c == r.Catalyst {
+			return r.Catalysts
+		}
+	}
+	return append([]string{r.Catalyst}, r.Catalysts...)
+}
+
+// ReactantRatio requires count(Numerator) >= Ratio * count(Denominator),
+// e.g. A:B >= 2:1 is {Numerator: "A", Denominator: "B", Ratio: 2}. A
+// Denominator of zero never satisfies it.
+type ReactantRatio struct {
+	Numerator   string  `json:"numerator"`
+	Denominator string  `json:"denominator"`
+	Ratio       float64 `json:"ratio"`
+}
+
+// Met reports whether the amounts num and den satisfy the ratio.
+func (q ReactantRatio) Met(num, den float64) bool {
+	return den > 0 && num >= q.Ratio*den
+}
+
+// Branch is one possible outcome of a branching reaction.
+type Branch struct {
+	Products    []string `json:"products"`
+	Probability float64  `json:"probability"`
+}
+
+// AllProducts returns the primary product followed by any by-products. For a
+// branching reaction it returns the products of every branch in turn.
+func (r Reaction) AllProducts() []string {
+	if len(r.Branches) > 0 {
+		var products []string
+		for _, b := range r.Branches {
+			products = append(products, b.Products...)
+		}
+		return products
+	}
+	return append([]string{r.Product}, r.ByProducts...)
+}
+
+// YieldedProducts returns the units one firing of a non-branching reaction
+// makes: Product repeated ProductYield times, then the by-products.
+func (r Reaction) YieldedProducts() []string {
+	products := make([]string, 0, max(r.ProductYield, 1)+len(r.ByProducts))
+	for i := 0; i < max(r.ProductYield, 1); i++ {
+		products = append(products, r.Product)
+	}
+	return append(products, r.ByProducts...)
+}
+
+// EffectiveRate returns the reaction's selection weight, treating an unset
+// rate as 1 and a disabled reaction as 0. A half-life decay is never
+// selected, so its weight is 0 too.
+func (r Reaction) EffectiveRate() float64 {
+	if r.Disabled || r.HalfLife > 0 {
+		return 0
+	}
+	if r.Rate <= 0 {
+		return 1
+	}
+	return r.Rate
+}
+
+// InhibitedRate returns r's effective rate at the pond's current counts,
+// reduced by product inhibition when r has it.
+func (p *Pond) InhibitedRate(r Reaction) float64 {
+	return r.EffectiveRate() / r.inhibition(float64(p.Molecules[r.Product]))
+}
+
+// inhibition returns the divisor product inhibition applies to r's rate
+// when its product amounts to product; 1 without inhibition.
+func (r Reaction) inhibition(product float64) float64 {
+	if r.ProductInhibition <= 0 {
+		return 1
+	}
+	return 1 + r.ProductInhibition*product
+}
+
+// IsAutocatalytic reports whether one of the reaction's products is one of its own catalysts.
+func (r Reaction) IsAutocatalytic() bool {
+	for _, c := range r.AllCatalysts() {
+		for _, product := range r.AllProducts() {
+			if c == product {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Pond represents the state of the simulation environment.
 type Pond struct {
-	Molecules    map[string]int // Molecule Name -> Count
-	Reactions    []Reaction
-	LastReaction string // To display in the UI
+	Seed       int64          // Seed the random source was initialized with
+	StepCount  int            // Number of calls to Step so far
+	Tick       int            // Ticks completed so far, by RunTo or the Game
+	Molecules  map[string]int // Molecule Name -> Count
+	Reactions  []Reaction
+	Notes      map[string]string // Species -> what it stands for, shown by the tutorial overlay
+	Status     string            // UI message (e.g. "Config saved"); cleared by the next fire, see LastReaction
+	lastFire   fireEvent
+	FireCounts []int // Successful fires per reaction index, since the last ResetStats
+	stats      windowStats
+
+	// Each pond owns its random source, so concurrent ponds never share state
+	// and a seed fully determines a run.
+	rng *cloneableRand
+
+	// StepsPerTick is the steps RunTo runs per tick; 0 means
+	// DefaultStepsPerTick. A Game runs its own StepsPerTick instead.
+	StepsPerTick int
+
+	// Lineage maps species -> producing reaction index -> units of the current
+	// population. Nil unless lineage tracking is enabled (see EnableLineage).
+	Lineage     map[string]map[int]int
+	lineageRand *rand.Rand
+
+	Controller *RateController // Optional feedback control of a degradation rate, applied once per tick
+
+	// Guarded re-checks every fire's reactants just before consuming them and
+	// refuses, logging an error, any fire that would drive a count negative,
+	// e.g. one reached through a path that skipped canFire.
+	Guarded     bool
+	GuardErrors int // Fires refused by the guard
+
+	// ProductionCaps limits how many units of a species may be made per tick
+	// (each call to Run); once a species reaches its cap, reactions producing
+	// it are skipped until the next tick. Reactions making a capped species
+	// are never integrated deterministically, so the cap holds in ODE and
+	// hybrid modes too.
+	ProductionCaps map[string]int
+	produced       map[string]int // Units made this tick of each capped species
+
+	// MaxCount is the carrying capacity of a species: a reaction that would
+	// raise its count above the maximum is skipped. Species without an entry
+	// are unbounded. ODE integration discards any amount above the maximum;
+	// injections are not clamped.
+	MaxCount map[string]int
+
+	// RateNoise is the standard deviation of the multiplicative Gaussian noise
+	// applied to every reaction's rate each step (0 disables). The noise comes
+	// from each reaction's own stream, so it never shifts the main stream.
+	RateNoise float64
+
+	// MutationRate is the probability that a copy made by an autocatalytic
+	// reaction is a new replicator species with a perturbed rate (see
+	// mutate). MutationSpread is the standard deviation of the mutant's log
+	// rate change.
+	MutationRate   float64
+	MutationSpread float64
+	mutants        int               // Mutants created so far, used to name new ones
+	mutantRoots    map[string]string // Mutant -> the original replicator it descends from
+
+	reactionRands []*cloneableRand // Per-reaction random streams, see reactionRand
+	streams       int              // Streams seeded so far, numbering the next one
+
+	// Gillespie switches reaction selection to the stochastic simulation
+	// algorithm: reactions are picked by propensity and Time advances by the
+	// waiting time between reactions.
+	Gillespie bool
+	Time      float64 // Simulated time in Gillespie and ODE mode
+
+	// Batch lets Run apply a run of repeated selections of one reaction in a
+	// single update. The counts follow the same distribution as stepping one
+	// fire at a time, but not the same random sequence. It is ignored when
+	// selection probabilities can change between steps (see batchable).
+	Batch bool
+
+	// ODEThreshold enables ODE mode when positive: while every reactant has
+	// at least this many molecules, Run integrates the deterministic rate
+	// equations instead of firing reactions (see integrateODE). Amounts holds
+	// the fractional counts meanwhile and is nil otherwise. With Hybrid set,
+	// only the reactions among abundant species are integrated and the rest
+	// keep firing stochastically (see runHybrid).
+	ODEThreshold int
+	Hybrid       bool
+	Amounts      map[string]float64
+
+	// Volume of the pond, used to convert counts to concentrations and to
+	// dilute the propensity of multi-molecule reactions. Zero means 1.
+	Volume float64
+
+	// Rounding turns fractional amounts (knockdown fractions, concentrations)
+	// into whole molecule counts.
+	Rounding RoundingMode
+
+	pending []sequestered // Catalyst units awaiting release, ordered by due step
+
+	observers []func(tick int, p *Pond) // Notified after every tick (see AddObserver)
+	Events    *EventLog                 // Receives every successful fire when set (see Game.LogEvents)
+
+	fireObservers []func(reaction, branch int) // Notified after every successful fire (see AddFireObserver)
+
+	neighbors []*Pond // Adjacent cells when the pond is a grid cell
+
+	weights       []float64 // Per-step selection weights; cached propensities during Run
+	propsCached   bool      // weights holds up-to-date propensities (Gillespie mode inside Run)
+	branchWeights []float64 // Scratch buffer for branch selection
+	index         *reactionIndex
 }
 
-// NewPond initializes the simulation with basic molecules and core reactions.
+// NewPond initializes the simulation with basic molecules and core reactions,
+// seeded from the current time.
 func NewPond() *Pond {
-	// Initialize random seed
-	rand.Seed(time.Now().UnixNano())
+	return NewPondWithSeed(time.Now().UnixNano())
+}
+
+// NewPondWithSeed initializes the default chemistry with a fixed seed.
+func NewPondWithSeed(seed int64) *Pond {
+	return NewPondWithKickstart(seed, DefaultKickstart)
+}
+
+// NewPondWithKickstart initializes the default chemistry with a fixed seed,
+// bootstrapping autocatalysis with kick instead of the default single E.
+func NewPondWithKickstart(seed int64, kick Kickstart) *Pond {
 
 	// Define initial basic molecules and their counts (A, B, C are the 'food' molecules)
 	initialMolecules := map[string]int{
@@ -53,8 +359,9 @@ func NewPond() *Pond {
 		"B": 500,
 		"C": 500,
 		"D": 0, // Complex molecule D (precursor)
-		"E": 1, // Start with one 'E' to kick off the autocatalysis immediately
+		"E": 0, // Complex molecule E (the autocatalyst)
 	}
+	kick.Apply(initialMolecules) // By default one 'E' to kick off the autocatalysis immediately
 
 	// Define core reactions.
 	// 1. Basic formation (A + B -> D)
@@ -62,176 +369,907 @@ func NewPond() *Pond {
 	// 3. Autocatalysis (D + A -> E, catalyzed by E) - The key self-reproducing reaction.
 	// 4. Degradation (E -> C + B) - To prevent infinite growth.
 	coreReactions := []Reaction{
-		{Reactants: []string{"A", "B"}, Product: "D", Catalyst: ""},  // R1: Basic synthesis
-		{Reactants: []string{"D", "C"}, Product: "E", Catalyst: ""},  // R2: Initial complex formation
-		{Reactants: []string{"D", "A"}, Product: "E", Catalyst: "E"}, // R3: Autocatalysis
-		{Reactants: []string{"E"}, Product: "A", Catalyst: ""},       // R4: Degradation/Recycling
+		{Reactants: []string{"A", "B"}, Product: "D", Description: "Basic synthesis: food combines into the precursor D"},
+		{Reactants: []string{"D", "C"}, Product: "E", Description: "Initial complex formation: the slow, uncatalyzed route to E"},
+		{Reactants: []string{"D", "A"}, Product: "E", Catalysts: []string{"E"}, Description: "Autocatalysis: E speeds up its own formation"},
+		{Reactants: []string{"E"}, Product: "A", Description: "Degradation/recycling: E breaks down, returning food"},
+	}
+	notes := map[string]string{
+		"A": "food molecule",
+		"B": "food molecule",
+		"C": "food molecule",
+		"D": "precursor complex, built from food",
+		"E": "the self-reproducing replicator (catalyzes its own formation)",
 	}
 
 	return &Pond{
-		Molecules:    initialMolecules,
-		Reactions:    coreReactions,
-		LastReaction: "Simulation Initialized",
+		Seed:           seed,
+		Molecules:      initialMolecules,
+		Reactions:      coreReactions,
+		Notes:          notes,
+		MutationSpread: DefaultMutationSpread,
+		Status:         message(MsgInitialized),
+		rng:            newCloneableRand(seed),
 	}
 }
 
-// Step runs one tick of the simulation.
-func (p *Pond) Step() {
-	if len(p.Reactions) == 0 {
-		p.LastReaction = "No reactions defined."
-		return
+// NewPondFromCounts creates a pond with the given counts and reactions,
+// seeded from the current time. Use it to warm-start an experiment from the
+// counts a previous run settled at. Both arguments are copied.
+func NewPondFromCounts(counts map[string]int, reactions []Reaction) *Pond {
+	molecules := make(map[string]int, len(counts))
+	for name, count := range counts {
+		molecules[name] = count
 	}
+	seed := time.Now().UnixNano()
+	return &Pond{
+		Seed:      seed,
+		Molecules: molecules,
+		Reactions: append([]Reaction(nil), reactions...),
+		Status:    message(MsgInitialized),
+		rng:       newCloneableRand(seed),
+	}
+}
 
-	// 1. Select a random reaction to attempt
-	r := p.Reactions[rand.Intn(len(p.Reactions))]
+// SpeciesNames returns the names of all molecules in the pond, sorted.
+func (p *Pond) SpeciesNames() []string {
+	names := make([]string, 0, len(p.Molecules))
+	for name := range p.Molecules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
 
-	// 2. Check reactants availability
-	canReact := true
-	for _, reactant := range r.Reactants {
-		if p.Molecules[reactant] <= 0 {
-			canReact = false
-			break
+// SpeciesByCount returns the names of all species, most abundant first.
+func (p *Pond) SpeciesByCount() []string {
+	return rankByCount(p.Molecules)
+}
+
+// rankByCount orders the species in counts by descending count, breaking
+// ties alphabetically so the order is stable between frames.
+func rankByCount(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
 		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// TotalPopulation returns the summed count of all molecules in the pond.
+func (p *Pond) TotalPopulation() int {
+	total := 0
+	for _, count := range p.Molecules {
+		total += count
+	}
+	return total
+}
+
+// selectReaction picks the index of the reaction to attempt, weighted by rate.
+// When every reaction has the default rate it falls back to a uniform pick.
+// It returns -1 if no reaction has a positive weight this step.
+func (p *Pond) selectReaction() int {
+	if p.Gillespie {
+		return p.gillespieSelect()
+	}
+	if p.RateNoise > 0 {
+		p.weights = p.fillNoisyRates(p.weights)
+		return p.pickWeighted(p.weights)
 	}
 
-	// 3. Check catalyst requirement
-	if canReact && r.Catalyst != "" {
-		// For catalyzed reactions, the catalyst must be present
-		if p.Molecules[r.Catalyst] <= 0 {
-			canReact = false
+	total := 0.0
+	uniform := true
+	for _, r := range p.Reactions {
+		rate := p.InhibitedRate(r)
+		total += rate
+		if rate != 1 {
+			uniform = false
 		}
 	}
+	if uniform {
+		return p.rng.Intn(len(p.Reactions))
+	}
+
+	if total <= 0 {
+		return -1
+	}
 
-	// 4. Execute the reaction if possible
-	if canReact {
-		// Consume reactants
-		for _, reactant := range r.Reactants {
-			p.Molecules[reactant]--
+	pick := p.rng.Float64() * total
+	last := -1
+	for i, r := range p.Reactions {
+		rate := p.InhibitedRate(r)
+		if rate <= 0 {
+			continue
+		}
+		pick -= rate
+		if pick < 0 {
+			return i
 		}
+		last = i
+	}
+	return last // Only reached through floating-point rounding
+}
 
-		// In this simplified model, we don't consume the catalyst.
-		// If the catalyst is the product (Autocatalysis, R3), it's conserved.
+// recordFire counts a successful fire of reaction idx, growing FireCounts
+// when reactions were added after the pond was created.
+func (p *Pond) recordFire(idx int) {
+	for len(p.FireCounts) < len(p.Reactions) {
+		p.FireCounts = append(p.FireCounts, 0)
+	}
+	p.FireCounts[idx]++
+}
 
-		// Produce product
-		p.Molecules[r.Product]++
+// pickWeighted picks an index from the main stream with probability
+// proportional to its weight, or -1 when all weights are zero.
+func (p *Pond) pickWeighted(weights []float64) int {
+	return pickWeighted(p.rng, weights)
+}
 
-		// Track reaction for UI
-		reactantsStr := ""
-		for i, rName := range r.Reactants {
-			reactantsStr += rName
-			if i < len(r.Reactants)-1 {
-				reactantsStr += " + "
-			}
+// pickWeighted picks an index with probability proportional to its weight,
+// drawing from rng, or -1 when all weights are zero.
+func pickWeighted(rng *cloneableRand, weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	pick := rng.Float64() * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		pick -= w
+		if pick < 0 {
+			return i
 		}
+		last = i
+	}
+	return last // Only reached through floating-point rounding
+}
+
+// firedBranch picks the branch one firing of reaction idx yields, drawing
+// from the reaction's own stream, or returns -1 for a reaction without branches.
+func (p *Pond) firedBranch(idx int) int {
+	r := p.Reactions[idx]
+	if len(r.Branches) == 0 {
+		return -1
+	}
+
+	p.branchWeights = p.branchWeights[:0]
+	for _, b := range r.Branches {
+		p.branchWeights = append(p.branchWeights, b.Probability)
+	}
+	rng := p.reactionRand(idx)
+	branch := pickWeighted(rng, p.branchWeights)
+	if branch < 0 {
+		branch = rng.Intn(len(r.Branches)) // No usable probabilities: treat branches as equally likely
+	}
+	return branch
+}
 
-		catalystStr := ""
-		if r.Catalyst != "" {
-			catalystStr = fmt.Sprintf(" (Cat: %s)", r.Catalyst)
+// produce adds one unit of species made by reaction idx.
+func (p *Pond) produce(species string, idx int) {
+	p.Molecules[species]++
+	p.countProduced(species)
+	if p.Lineage != nil {
+		p.recordProduced(species, idx)
+	}
+}
+
+// fireEvent identifies the most recent successful fire.
+type fireEvent struct {
+	Reaction int  // Index into Reactions
+	Branch   int  // Branch taken, or -1
+	valid    bool // False until something has fired
+}
+
+// LastReaction describes the latest event for the UI: the status message if
+// one was set since the last fire, otherwise the last fired reaction, e.g.
+// "Reaction: D + A -> E (Cat: E)". The text is built on demand, so stepping
+// never pays for it.
+func (p *Pond) LastReaction() string {
+	if p.Status != "" || !p.lastFire.valid || p.lastFire.Reaction >= len(p.Reactions) {
+		return p.Status
+	}
+
+	return "Reaction: " + p.describeFire(p.lastFire)
+}
+
+// describeFire formats a fire as e.g. "D + A -> E (Cat: E)", with the
+// products of the branch taken.
+func (p *Pond) describeFire(e fireEvent) string {
+	if e.Reaction < 0 || e.Reaction >= len(p.Reactions) {
+		return "?"
+	}
+	r := p.Reactions[e.Reaction]
+	products := append([]string{r.Product}, r.ByProducts...)
+	if e.Branch >= 0 && e.Branch < len(r.Branches) {
+		products = r.Branches[e.Branch].Products
+	}
+	catalystStr := ""
+	if catalysts := r.AllCatalysts(); len(catalysts) > 0 {
+		catalystStr = fmt.Sprintf(" (Cat: %s)", strings.Join(catalysts, ", "))
+	}
+	return fmt.Sprintf("%s -> %s%s", strings.Join(r.Reactants, " + "), strings.Join(products, " + "), catalystStr)
+}
+
+// countOf returns how many times name occurs in names.
+func countOf(names []string, name string) int {
+	n := 0
+	for _, other := range names {
+		if other == name {
+			n++
+		}
+	}
+	return n
+}
+
+// checkReactants returns an error if firing r would drive a reactant's
+// count negative.
+func (p *Pond) checkReactants(r Reaction) error {
+	for k, reactant := range r.Reactants {
+		if need := countOf(r.Reactants, reactant); countOf(r.Reactants[:k], reactant) == 0 && p.Molecules[reactant] < need {
+			return fmt.Errorf("%s needs %d %s but only %d are present", r, need, reactant, p.Molecules[reactant])
 		}
-		p.LastReaction = fmt.Sprintf("Reaction: %s -> %s%s", reactantsStr, r.Product, catalystStr)
+	}
+	return nil
+}
+
+// canFire reports whether r's reactants and catalysts are present, their
+// ratio is high enough, no product would pass its production cap or maximum
+// count and the pond is dense enough for it.
+func (p *Pond) canFire(r Reaction) bool {
+	// A repeated reactant needs one unit per occurrence: 2A needs two A's
+	for k, reactant := range r.Reactants {
+		if p.Molecules[reactant] < countOf(r.Reactants[:k+1], reactant) {
+			return false
+		}
+	}
+	if !p.ratioMet(r) {
+		return false
+	}
+	if !p.withinCaps(r) || !p.belowMax(r) {
+		return false
+	}
+
+	// For catalyzed reactions, every catalyst must be present
+	for _, catalyst := range r.AllCatalysts() {
+		if p.catalystCount(r, catalyst) <= 0 {
+			return false
+		}
+	}
+
+	return r.MinTotalPopulation <= 0 || p.TotalPopulation() >= r.MinTotalPopulation
+}
+
+// ratioMet reports whether r's MinReactantRatio, if any, holds at the current counts.
+func (p *Pond) ratioMet(r Reaction) bool {
+	q := r.MinReactantRatio
+	return q == nil || q.Met(float64(p.Molecules[q.Numerator]), float64(p.Molecules[q.Denominator]))
+}
+
+// succeeds draws whether reaction idx, selected and able to fire, actually
+// does so. Only reactions with a SuccessProbability below 1 use a draw, from
+// their own stream, so other reactions' randomness is unaffected.
+func (p *Pond) succeeds(idx int) bool {
+	prob := p.Reactions[idx].SuccessProbability
+	if prob <= 0 || prob >= 1 {
+		return true
+	}
+	return p.reactionRand(idx).Float64() < prob
+}
+
+// Step runs one tick of the simulation.
+func (p *Pond) Step() {
+	p.StepCount++
+	p.releaseSequestered()
+
+	if len(p.Reactions) == 0 {
+		p.Status = message(MsgNoReactions)
+		return
+	}
+
+	// 1. Select a random reaction to attempt
+	idx := p.selectReaction()
+	if idx < 0 {
+		return
+	}
+	r := p.Reactions[idx]
+
+	// 2. Check reactants, catalysts and density
+	canReact := p.canFire(r)
+
+	// 3. Execute the reaction if possible and it does not fail intrinsically
+	if canReact && p.succeeds(idx) {
+		p.fire(idx)
 	} else {
 		// If a reaction fails, we keep the last successful event for better visualization clarity.
 		// To avoid overwhelming the status display with constant "failed" messages, we skip the update.
 	}
 }
 
+// fire executes reaction idx, which must be able to fire: it consumes the
+// reactants, holds back stoichiometric catalysts, makes the products and
+// records the event.
+func (p *Pond) fire(idx int) {
+	r := p.Reactions[idx]
+	if p.Guarded {
+		if err := p.checkReactants(r); err != nil {
+			p.GuardErrors++
+			p.Status = fmt.Sprintf("R%d blocked: %v", idx+1, err)
+			logf(LevelError, "step %d: R%d blocked: %v", p.StepCount, idx+1, err)
+			return
+		}
+	}
+	p.recordFire(idx)
+
+	// Consume reactants
+	for _, reactant := range r.Reactants {
+		p.Molecules[reactant]--
+		if p.Lineage != nil {
+			p.recordConsumed(reactant)
+		}
+	}
+
+	// In this simplified model, we don't consume the catalyst.
+	// If the catalyst is the product (Autocatalysis, R3), it's conserved.
+	// Stoichiometric catalysts are the exception: they are held back for a while.
+	if r.CatalystDelay > 0 && !r.NeighborCatalyzed {
+		p.sequester(r.AllCatalysts(), idx, r.CatalystDelay)
+	}
+
+	// Produce product(s); an emergent species is registered by its first increment
+	branch := p.firedBranch(idx)
+	if branch < 0 {
+		for _, product := range r.YieldedProducts() {
+			p.produce(product, idx)
+		}
+	} else {
+		for _, product := range r.Branches[branch].Products {
+			p.produce(product, idx)
+		}
+	}
+	if p.Events != nil {
+		p.Events.record(p, idx, branch)
+	}
+	for _, fn := range p.fireObservers {
+		fn(idx, branch)
+	}
+
+	if p.MutationRate > 0 && branch < 0 {
+		p.mutate(idx)
+	}
+
+	// Track reaction for UI; the text is only built when asked for
+	p.Status = ""
+	p.lastFire = fireEvent{Reaction: idx, Branch: branch, valid: true}
+	if p.propsCached {
+		p.refreshPropensities(p.reactionIndex().affects[idx])
+	}
+	if logLevel >= LevelDebug {
+		logf(LevelDebug, "step %d: %s", p.StepCount, p.LastReaction())
+	}
+}
+
 // --- Ebitengine Game Implementation ---
 
 // Game implements ebiten.Game and holds the simulation state.
 type Game struct {
-	Pond        *Pond
-	TickCounter int
+	Pond               *Pond
+	TickCounter        int
+	StepsPerTick       int    // Simulation steps run per tick
+	RenderEvery        int    // Ticks run per frame; History samples only every RenderEvery-th tick
+	EmergenceThreshold int    // E count at which the CAS is considered dominant
+	Focus              string // Species the detail views (e.g. lineage) are about
+	LogBars            bool   // Scale count bars logarithmically instead of linearly
+	BarDivisor         int    // Molecules per pixel of a linear bar; 0 scales to the highest count seen
+	AntiAlias          bool   // Draw bars and graph lines anti-aliased (slower, smoother)
+	RankByCount        bool   // Sort the molecule table by descending count instead of by name
+	ShowSimTime        bool   // Show simulated time instead of the tick count on the status line
+
+	Emergence *EmergenceCondition // Replaces the E threshold test when set
+
+	KnockdownFraction float64 // Share of the focused species removed by the knockdown key
+	TimeStep          float64 // Simulated seconds per tick, for the status line's time display
+	ResetStatsAt      int     // Tick at which the statistics are reset, ending the transient (0 never)
+
+	History        *History        // Downsampled counts for the whole run, plotted by the live graph
+	GraphSelection map[string]bool // Species plotted on the graph; toggled by clicking table rows
+	HighWater      HighWater       // All-time maximum count per species
+	CompactHUD     bool            // Show only tick and emergence status, giving the graph the table's space
+	ShowNetwork    bool            // Show the network statistics panel
+	ShowDominance  bool            // Overlay the dominance fraction on the graph
+	Dominance      *History        // Dominance fraction history, in parts per thousand
+	Theme          Theme           // Display palette; cycled by the theme key
+	ColorBands     []ColorBand     // Count-dependent bar colors; override the species' bar colors
+	ShowRecent     bool            // Show the recent events panel
+	ShowTutorial   bool            // Explain the species and reactions in an overlay
+	Recent         *eventRing      // Latest fires, for the recent events panel
+	frameFires     frameTally      // Fires of each reaction during the latest frame
+	recentFrozen   []recentEvent   // Snapshot shown while the panel is frozen; nil when following
+
+	ConfigPath string // Where the save key writes the current configuration
+
+	Setup     bool // Editing the starting counts; the run starts when this is cleared
+	SetupStep int  // Molecules added or removed per setup adjustment
+	setupRow  int  // Table row being edited in setup
+
+	Injecting   bool   // Typing a "species amount" line to add molecules
+	InjectInput string // Text typed so far in injection mode
+
+	CheckpointEvery int    // Save a checkpoint every N ticks (0 disables)
+	CheckpointPath  string // Base path; checkpoints rotate between two files derived from it
+
+	ScreenshotOnEmergence bool // Save a PNG of the frame on which emergence is first seen
+	emergenceShot         emergenceTrigger
+
+	idle quiescenceDetector // Stepping pauses while a frame passes without fires
+
+	mu     sync.Mutex   // Guards the pond against the HTTP server's handlers while it changes
+	server *http.Server // Serves the game's state when started with Serve
 }
 
 func NewGame() *Game {
-	return &Game{
-		Pond: NewPond(),
+	return newGameWithPond(NewPond())
+}
+
+// newGameWithPond wraps an existing pond in a Game with default settings.
+func newGameWithPond(p *Pond) *Game {
+	g := &Game{
+		Pond:               p,
+		StepsPerTick:       DefaultStepsPerTick,
+		RenderEvery:        1,
+		EmergenceThreshold: DefaultEmergenceThreshold,
+		Focus:              "E",
+		BarDivisor:         DefaultBarDivisor,
+		KnockdownFraction:  DefaultKnockdownFraction,
+		TimeStep:           DefaultTimeStep,
+		SetupStep:          DefaultSetupStep,
+		History:            NewHistory(HistoryCapacity),
+		Dominance:          NewHistory(HistoryCapacity),
+		GraphSelection:     map[string]bool{"D": true, "E": true},
+		HighWater:          HighWater{},
+		Theme:              themes[DefaultTheme],
+		Recent:             newEventRing(RecentEventsCapacity),
+	}
+
+	// The graph's data and the event alerts are fed by tick observers
+	p.AddObserver(func(tick int, p *Pond) {
+		if g.RenderEvery <= 1 || tick%g.RenderEvery == 0 {
+			g.History.Add(tick, p.Molecules)
+			g.Dominance.Add(tick, map[string]int{dominanceKey: int(dominanceScale * p.DominanceFraction())})
+		}
+	})
+	p.AddObserver(func(_ int, p *Pond) { g.HighWater.Observe(p.Molecules) })
+	p.AddObserver(g.tickAlerts())
+	p.AddFireObserver(func(reaction, branch int) {
+		g.Recent.Add(recentEvent{Tick: g.TickCounter + 1, fireEvent: fireEvent{Reaction: reaction, Branch: branch, valid: true}})
+		g.frameFires.Add(reaction)
+	})
+	return g
+}
+
+// statusClock formats the status line's clock: "Sim Ticks: N", or with
+// showTime the simulated time the ticks amount to, "Sim Time: X s".
+func statusClock(showTime bool, ticks int, timeStep float64) string {
+	if showTime {
+		return fmt.Sprintf("Sim Time: %.4g s", float64(ticks)*timeStep)
+	}
+	return fmt.Sprintf("Sim Ticks: %d", ticks)
+}
+
+// tableSpecies returns the rows of the molecule table: alphabetical, or
+// ranked by current abundance when RankByCount is set. Setup keeps the
+// alphabetical order so rows do not move while counts are edited.
+func (g *Game) tableSpecies() []string {
+	if g.RankByCount && !g.Setup {
+		return g.Pond.SpeciesByCount()
+	}
+	return g.Pond.SpeciesNames()
+}
+
+// cycleFocus moves the focus to the next species in alphabetical order.
+func (g *Game) cycleFocus() {
+	names := g.Pond.SpeciesNames()
+	for i, name := range names {
+		if name == g.Focus {
+			g.Focus = names[(i+1)%len(names)]
+			return
+		}
+	}
+	if len(names) > 0 {
+		g.Focus = names[0]
 	}
 }
 
 // Update updates the game state. This is where the simulation steps run.
 func (g *Game) Update() error {
-	// Run multiple simulation steps per frame for fast evolution
-	for i := 0; i < StepsPerTick; i++ {
-		g.Pond.Step()
+	g.mu.Lock()
+	setup := g.Setup
+	if g.Injecting {
+		g.updateInjection()
+	} else if setup {
+		g.updateSetup()
+	} else {
+		g.handleInput()
 	}
-	g.TickCounter++
+	g.mu.Unlock()
+	if setup {
+		return nil // Nothing runs until the starting counts are confirmed
+	}
+
+	// A quiescent pond is not stepped again until a perturbation (an
+	// injection, a re-enabled reaction) lets something fire
+	if g.idle.Quiescent() && !g.Pond.canProgress() {
+		return nil
+	}
+
+	// Run multiple simulation steps per frame for fast evolution; long runs
+	// may also run several whole ticks between frames
+	g.frameFires.Reset()
+	for i := 0; i < max(g.RenderEvery, 1); i++ {
+		g.advance(g.StepsPerTick)
+	}
+	g.idle.Observe(g.Pond.TotalFires())
 	return nil
 }
 
+// handleInput processes the keyboard shortcuts and mouse clicks.
+func (g *Game) handleInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		g.Injecting = true
+		g.InjectInput = ""
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.cycleFocus()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.LogBars = !g.LogBars
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.RankByCount = !g.RankByCount
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		g.ShowSimTime = !g.ShowSimTime
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		g.CompactHUD = !g.CompactHUD
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		g.ShowNetwork = !g.ShowNetwork
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.ResetStats()
+		g.Pond.Status = fmt.Sprintf("Statistics reset at tick %d", g.TickCounter)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
+		g.ShowTutorial = !g.ShowTutorial
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		g.ShowDominance = !g.ShowDominance
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) {
+		g.ShowRecent = !g.ShowRecent
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyZ) {
+		g.toggleRecentFreeze()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.Theme = nextTheme(g.Theme)
+		g.Pond.Status = fmt.Sprintf("Theme: %s", g.Theme.Name)
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		if name, ok := g.speciesRowAt(x, y); ok {
+			g.GraphSelection[name] = !g.GraphSelection[name]
+		}
+	}
+	for i, key := range reactionToggleKeys {
+		if i < len(g.Pond.Reactions) && inpututil.IsKeyJustPressed(key) {
+			r := &g.Pond.Reactions[i]
+			r.Disabled = !r.Disabled
+			g.Pond.Status = fmt.Sprintf("R%d enabled: %t", i+1, !r.Disabled)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		if err := g.SaveConfig(g.ConfigPath); err != nil {
+			g.Pond.Status = fmt.Sprintf("Save failed: %v", err)
+		} else {
+			g.Pond.Status = fmt.Sprintf("Config saved to %s", g.ConfigPath)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		removed := g.Pond.Knockdown(g.Focus, g.KnockdownFraction)
+		g.Pond.Status = fmt.Sprintf("Knockdown: removed %d %s", removed, g.Focus)
+	}
+}
+
+// reactionToggleKeys enable or disable reactions R1 to R9.
+var reactionToggleKeys = []ebiten.Key{
+	ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3,
+	ebiten.KeyDigit4, ebiten.KeyDigit5, ebiten.KeyDigit6,
+	ebiten.KeyDigit7, ebiten.KeyDigit8, ebiten.KeyDigit9,
+}
+
+// advance runs one tick of n simulation steps and the per-tick bookkeeping
+// shared by the GUI and headless runners.
+func (g *Game) advance(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Pond.Tick = g.TickCounter // The game's counter is the reference, e.g. after loading a snapshot
+	g.Pond.runTick(n)
+	g.TickCounter = g.Pond.Tick
+	if g.ResetStatsAt > 0 && g.TickCounter == g.ResetStatsAt {
+		g.ResetStats()
+	}
+
+	if g.CheckpointEvery > 0 && g.TickCounter%g.CheckpointEvery == 0 {
+		if err := g.checkpoint(); err != nil {
+			logf(LevelError, "checkpoint failed: %v", err)
+		}
+	}
+}
+
+// RunTo fast-forwards the game to the given tick without drawing, following
+// the trajectory the window would: each tick runs StepsPerTick steps and the
+// same per-tick bookkeeping. Two games with the same seed and settings reach
+// identical counts. It does nothing if the game is already at or past tick.
+func (g *Game) RunTo(tick int) {
+	for g.TickCounter < tick {
+		g.advance(g.StepsPerTick)
+	}
+}
+
+// runTick runs one tick of n steps and the per-tick work that goes with it:
+// half-life decay, the rate controller and the tick observers.
+func (p *Pond) runTick(n int) {
+	p.Run(n)
+	p.Decay()
+	p.Tick++
+	if p.Controller != nil {
+		p.Controller.Regulate(p)
+	}
+	p.notifyObservers(p.Tick)
+}
+
+// RunTo fast-forwards the pond to the given tick headlessly, running
+// StepsPerTick steps per tick with the same per-tick work as a Game. A pond
+// therefore follows the trajectory a Game with the same seed and
+// StepsPerTick would, and two identically seeded ponds reach identical
+// counts. It does nothing if the pond is already at or past tick.
+func (p *Pond) RunTo(tick int) {
+	steps := p.StepsPerTick
+	if steps <= 0 {
+		steps = DefaultStepsPerTick
+	}
+	for p.Tick < tick {
+		p.runTick(steps)
+	}
+}
+
+// Molecule table layout. Rows start two row heights below the header.
+const (
+	tableHeaderY = 100
+	tableRowStep = 20
+	tableNameX   = 20
+	tableCountX  = 120
+)
+
+// speciesRowAt returns the species whose table row (name and count columns)
+// contains the screen position, if any.
+func (g *Game) speciesRowAt(x, y int) (string, bool) {
+	table := computeLayout(g.CompactHUD, ScreenWidth, ScreenHeight).Table
+	if !image.Pt(x, y).In(table) || x >= tableCountX+80 {
+		return "", false
+	}
+	// Row baselines start two steps below the header; a row spans the step ending just below its baseline
+	firstRowTop := tableHeaderY + tableRowStep + 5
+	if y < firstRowTop {
+		return "", false
+	}
+	names := g.tableSpecies()
+	row := (y - firstRowTop) / tableRowStep
+	if row >= len(names) {
+		return "", false
+	}
+	return names[row], true
+}
+
+// logBarDecades is how many powers of ten the full bar width spans in log mode.
+const logBarDecades = 6
+
+// barDivisor returns the molecules per pixel of the linear bars: BarDivisor
+// when set, otherwise just enough for the highest count seen so far to fill
+// maxWidth.
+func (g *Game) barDivisor(maxWidth int) int {
+	if g.BarDivisor > 0 {
+		return g.BarDivisor
+	}
+	peak := 0
+	for _, count := range g.HighWater {
+		peak = max(peak, count)
+	}
+	return max((peak+maxWidth-1)/max(maxWidth, 1), 1)
+}
+
+// barWidth maps a molecule count to a bar width in pixels, capped at maxWidth.
+// Linear mode uses one pixel per divisor molecules (at least 1); log mode
+// spreads logBarDecades decades across the bar so small and huge counts stay
+// visible together. Zero (or negative) counts always yield no bar.
+func barWidth(count, maxWidth, divisor int, logScale bool) int {
+	if count <= 0 {
+		return 0
+	}
+
+	width := count / max(divisor, 1)
+	if logScale {
+		width = int(math.Log10(float64(count)+1) / logBarDecades * float64(maxWidth))
+	}
+	if width > maxWidth {
+		width = maxWidth // Cap the bar width
+	}
+	return width
+}
+
 // Draw draws the game screen.
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.Fill(color.Black) // Dark background for contrast
+	screen.Fill(color.Black)         // Dark background for contrast
+	defer g.captureEmergence(screen) // After everything else is drawn
+	layout := computeLayout(g.CompactHUD, ScreenWidth, ScreenHeight)
+	g.drawHeatStrip(screen, layout.Strip)
+
+	// Compact HUD: just the essentials and a large graph
+	if g.CompactHUD {
+		emergence := "not yet"
+		if g.Emerged() {
+			emergence = "ACHIEVED"
+		}
+		hud := fmt.Sprintf("%s | CAS dominance: %s", statusClock(g.ShowSimTime, g.TickCounter, g.TimeStep), emergence)
+		text.Draw(screen, hud, basicfont.Face7x13, 20, 30, color.White)
+		g.drawGraph(screen, layout.Graph)
+		return
+	}
 
 	// Title
 	title := "Autocatalytic Pond Simulation (Ebitengine)"
 	text.Draw(screen, title, basicfont.Face7x13, 20, 30, color.White)
 
 	// Simulation Status
-	status := fmt.Sprintf("Sim Ticks: %d | Steps/Tick: %d", g.TickCounter, StepsPerTick)
+	status := fmt.Sprintf("%s | Steps/Tick: %d", statusClock(g.ShowSimTime, g.TickCounter, g.TimeStep), g.StepsPerTick)
+	if g.idle.Quiescent() {
+		status += " | Quiescent"
+	}
 	text.Draw(screen, status, basicfont.Face7x13, 20, 50, color.White)
 
-	text.Draw(screen, "Last Event:", basicfont.Face7x13, 20, 70, color.RGBA{180, 180, 180, 255})
-	text.Draw(screen, g.Pond.LastReaction, basicfont.Face7x13, 100, 70, color.White)
+	// Total propensity shows how active the system is; near zero means it has stalled
+	propensity := g.Pond.TotalPropensity()
+	activity := fmt.Sprintf("Propensity: %.4g", propensity)
+	if g.Pond.Gillespie {
+		activity += fmt.Sprintf(" | Time: %.4g", g.Pond.Time)
+		if propensity > 0 {
+			activity += fmt.Sprintf(" | Mean wait: %.3g", 1/propensity)
+		}
+	}
+	text.Draw(screen, activity, basicfont.Face7x13, 400, 50, color.White)
+
+	if g.Injecting {
+		text.Draw(screen, "Inject (species amount, Enter/Esc): "+g.InjectInput+"_", basicfont.Face7x13, 20, 70, color.RGBA{255, 255, 0, 255})
+	} else if g.Setup {
+		text.Draw(screen, g.setupHint(), basicfont.Face7x13, 20, 70, color.RGBA{255, 255, 0, 255})
+	} else {
+		text.Draw(screen, "Last Event:", basicfont.Face7x13, 20, 70, g.Theme.Dim)
+		text.Draw(screen, g.Pond.LastReaction(), basicfont.Face7x13, 100, 70, color.White)
+		if summary := g.frameSummary(); summary != "" {
+			text.Draw(screen, "This frame:", basicfont.Face7x13, 20, 86, g.Theme.Dim)
+			text.Draw(screen, summary, basicfont.Face7x13, 100, 86, color.White)
+		}
+	}
 
 	// Molecule Visualization
-	yOffset := 100
-	xName := 20
-	xCount := 120
+	yOffset := tableHeaderY
+	xName := tableNameX
+	xCount := tableCountX
 
-	text.Draw(screen, "Molecule", basicfont.Face7x13, xName, yOffset, color.RGBA{100, 200, 255, 255})
-	text.Draw(screen, "Count", basicfont.Face7x13, xCount, yOffset, color.RGBA{100, 200, 255, 255})
+	text.Draw(screen, "Molecule", basicfont.Face7x13, xName, yOffset, g.Theme.Header)
+	countHeader := "Count"
+	if g.RankByCount {
+		countHeader = "Count (ranked)"
+	}
+	text.Draw(screen, countHeader, basicfont.Face7x13, xCount, yOffset, g.Theme.Header)
 
-	yOffset += 20
+	yOffset += tableRowStep
 
 	// Draw molecule counts, highlighting the critical CAS molecule 'E'
-	for name, count := range g.Pond.Molecules {
-		yOffset += 20
+	for row, name := range g.tableSpecies() {
+		count := g.Pond.Molecules[name]
+		yOffset += tableRowStep
 
-		// Color logic:
-		molColor := color.White // Default for basic molecules (A, B, C)
+		// Color logic: every species gets a stable hashed color unless highlighted below
+		molColor := g.Theme.SpeciesColor(name)
 
 		// Simple visual feedback: size of the rectangle represents molecule count
 		rectMax := ScreenWidth - xCount - 150
 		rectHeight := 15
-		rectWidth := count / 5
-		if rectWidth > rectMax {
-			rectWidth = rectMax // Cap the bar width
-		}
-		if rectWidth < 0 {
-			rectWidth = 0
-		}
+		rectWidth := barWidth(count, rectMax, g.barDivisor(rectMax), g.LogBars)
 
-		barColor := color.RGBA{50, 50, 50, 150} // Default grey bar
+		barColor := molColor
+		barColor.A = 100 // Faded version of the species color
 
+		cue := ""
 		if name == "D" {
-			molColor = color.RGBA{255, 255, 0, 255} // Yellow for Precursor
-			barColor = color.RGBA{255, 255, 0, 100}
+			barColor = g.Theme.PrecursorBar
 		} else if name == "E" {
-			// Red/Orange highlight for the Autocatalytic product
-			molColor = color.RGBA{255, 100, 50, 255}
-			barColor = color.RGBA{255, 0, 0, 100} // Faded red bar
+			barColor = g.Theme.ProductBar
 
 			// Check for CAS Emergence based on absolute count
-			if count > 5000 {
-				molColor = color.RGBA{0, 255, 0, 255} // Green when dominant
+			if count > g.EmergenceThreshold {
+				molColor = g.Theme.Dominant
+				cue = g.Theme.DominantCue
 			}
 		}
+		if band, ok := bandColor(g.ColorBands, count); ok {
+			barColor = band
+		}
 
 		// Draw the dynamic bar
-		ebiten.DrawRect(screen, rectWidth, rectHeight, barColor, &ebiten.DrawRectOptions{
-			GeoM: ebiten.Translate(float64(xCount+80), float64(yOffset-11)),
-		})
+		g.renderer().fillRect(screen, float32(xCount+80), float32(yOffset-11), float32(rectWidth), float32(rectHeight), barColor)
 
-		// Draw molecule name and count
+		// Draw molecule name and count, marking species pinned to the graph
+		if g.GraphSelection[name] {
+			text.Draw(screen, "*", basicfont.Face7x13, xName-12, yOffset, molColor)
+		}
+		if g.Setup && row == g.setupRow {
+			text.Draw(screen, ">", basicfont.Face7x13, xName-20, yOffset, color.RGBA{255, 255, 0, 255})
+		}
 		text.Draw(screen, name, basicfont.Face7x13, xName, yOffset, molColor)
 		text.Draw(screen, strconv.Itoa(count), basicfont.Face7x13, xCount, yOffset, molColor)
+		if cue != "" {
+			text.Draw(screen, cue, basicfont.Face7x13, xCount+80+rectMax+5, yOffset, molColor) // Just past the longest bar
+		}
+	}
+
+	g.drawGraph(screen, layout.Graph)
+	if g.ShowDominance {
+		g.drawDominance(screen, layout.Graph)
+	}
+	if g.ShowNetwork {
+		g.drawNetworkStats(screen)
+	}
+	if g.ShowRecent {
+		g.drawRecentEvents(screen)
+	}
+
+	// Controlled degradation rate
+	if c := g.Pond.Controller; c != nil && c.Reaction >= 0 && c.Reaction < len(g.Pond.Reactions) {
+		controlText := fmt.Sprintf("Controller: %s -> %d | R%d rate %.3f", c.Species, c.Target, c.Reaction+1, g.Pond.Reactions[c.Reaction].EffectiveRate())
+		text.Draw(screen, controlText, basicfont.Face7x13, xName, ScreenHeight-70, g.Theme.Dim)
+	}
+
+	// Production sources of the focused species
+	if g.Pond.Lineage != nil {
+		lineageText := fmt.Sprintf("Sources of %s: %s", g.Focus, g.Pond.LineageSummary(g.Focus))
+		text.Draw(screen, lineageText, basicfont.Face7x13, xName, ScreenHeight-50, g.Theme.Dim)
 	}
 
 	// Final Emergence Message
-	if g.Pond.Molecules["E"] > 5000 {
-		emergenceText := fmt.Sprintf("!!! CAS DOMINANCE ACHIEVED (E: %d) !!!", g.Pond.Molecules["E"])
-		text.Draw(screen, emergenceText, basicfont.Face7x13, xName, ScreenHeight-30, color.RGBA{0, 255, 0, 255})
+	if g.Emerged() {
+		emergenceText := message(MsgEmergence, g.Pond.Molecules["E"])
+		text.Draw(screen, emergenceText, basicfont.Face7x13, xName, ScreenHeight-30, g.Theme.Dominant)
+	}
+
+	if g.ShowTutorial {
+		g.drawTutorial(screen)
 	}
 }
 
@@ -240,12 +1278,23 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 	return ScreenWidth, ScreenHeight
 }
 
-// The new main function runs the Ebitengine game loop.
+// The new main function dispatches to a subcommand; with none it runs the Ebitengine game loop.
 func main() {
+	cmd, args, err := findCommand(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprint(os.Stderr, usage())
+		os.Exit(2)
+	}
+	if err := cmd.Run(args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runGUI opens the window and runs the game until it is closed.
+func runGUI(game *Game) error {
 	ebiten.SetWindowSize(ScreenWidth, ScreenHeight)
 	ebiten.SetWindowTitle("Go Autocatalytic Set - Ebitengine")
 
-	if err := ebiten.RunGame(NewGame()); err != nil {
-		log.Fatal(err)
-	}
+	return ebiten.RunGame(game)
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// --- Stoichiometry Balancing ---
+
+// Limits on the coefficient search in BalanceReaction.
+const (
+	MaxBalanceCoefficient = 8 // Largest coefficient tried for any species
+	MaxBalanceSpecies     = 6 // Largest number of distinct species accepted
+)
+
+// BalanceReaction finds the smallest positive integer coefficients (up to
+// MaxBalanceCoefficient) for which the total mass of the reactants equals the
+// total mass of the products. "Smallest" means the lowest coefficient sum; ties
+// go to the first solution in search order. It returns an error when a mass is
+// missing or non-positive, or when no balancing exists within the limits.
+func BalanceReaction(reactants, products []string, masses map[string]float64) (map[string]int, map[string]int, error) {
+	if len(reactants) == 0 || len(products) == 0 {
+		return nil, nil, fmt.Errorf("balance: need at least one reactant and one product")
+	}
+	lhs, rhs := dedupe(reactants), dedupe(products)
+	if len(lhs)+len(rhs) > MaxBalanceSpecies {
+		return nil, nil, fmt.Errorf("balance: at most %d species supported, got %d", MaxBalanceSpecies, len(lhs)+len(rhs))
+	}
+
+	// Reactant masses count positive and product masses negative, so a balanced
+	// assignment sums to zero.
+	species := append(append([]string{}, lhs...), rhs...)
+	weights := make([]float64, len(species))
+	scale := 0.0
+	for i, name := range species {
+		m, ok := masses[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("balance: no mass for %q", name)
+		}
+		if m <= 0 {
+			return nil, nil, fmt.Errorf("balance: mass of %q must be positive, got %g", name, m)
+		}
+		weights[i] = m
+		if i >= len(lhs) {
+			weights[i] = -m
+		}
+		scale = math.Max(scale, m)
+	}
+	tolerance := 1e-9 * scale
+
+	coeffs := make([]int, len(species))
+	var best []int
+	bestSum := math.MaxInt
+	var search func(i int, sum int, total float64)
+	search = func(i int, sum int, total float64) {
+		if sum+(len(species)-i) >= bestSum {
+			return // Can't beat the best solution even with all remaining coefficients at 1
+		}
+		if i == len(species) {
+			if math.Abs(total) <= tolerance {
+				best = append(best[:0], coeffs...)
+				bestSum = sum
+			}
+			return
+		}
+		for c := 1; c <= MaxBalanceCoefficient; c++ {
+			coeffs[i] = c
+			search(i+1, sum+c, total+float64(c)*weights[i])
+		}
+	}
+	search(0, 0, 0)
+
+	if best == nil {
+		return nil, nil, fmt.Errorf("balance: no mass-conserving coefficients up to %d", MaxBalanceCoefficient)
+	}
+	reactantCoeffs := make(map[string]int, len(lhs))
+	productCoeffs := make(map[string]int, len(rhs))
+	for i, name := range species {
+		if i < len(lhs) {
+			reactantCoeffs[name] = best[i]
+		} else {
+			productCoeffs[name] = best[i]
+		}
+	}
+	return reactantCoeffs, productCoeffs, nil
+}
+
+// dedupe returns names without repeats, keeping first occurrences in order.
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBalanceReaction(t *testing.T) {
+	tests := []struct {
+		name                string
+		reactants, products []string
+		masses              map[string]float64
+		wantLHS, wantRHS    map[string]int
+	}{
+		{"already balanced", []string{"A", "B"}, []string{"D"},
+			map[string]float64{"A": 1, "B": 2, "D": 3},
+			map[string]int{"A": 1, "B": 1}, map[string]int{"D": 1}},
+		{"water", []string{"H2", "O2"}, []string{"H2O"},
+			map[string]float64{"H2": 2, "O2": 32, "H2O": 18},
+			map[string]int{"H2": 2, "O2": 1}, map[string]int{"H2O": 2}},
+		{"fractional ratio", []string{"A"}, []string{"B"},
+			map[string]float64{"A": 1, "B": 1.5},
+			map[string]int{"A": 3}, map[string]int{"B": 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lhs, rhs, err := BalanceReaction(tt.reactants, tt.products, tt.masses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(lhs, tt.wantLHS) || !reflect.DeepEqual(rhs, tt.wantRHS) {
+				t.Errorf("got %v -> %v, want %v -> %v", lhs, rhs, tt.wantLHS, tt.wantRHS)
+			}
+		})
+	}
+}
+
+func TestBalanceReactionErrors(t *testing.T) {
+	tests := []struct {
+		name                string
+		reactants, products []string
+		masses              map[string]float64
+	}{
+		{"beyond coefficient limit", []string{"A"}, []string{"B"}, map[string]float64{"A": 1, "B": 100}},
+		{"irrational ratio", []string{"A"}, []string{"B"}, map[string]float64{"A": 1, "B": 1.41421356}},
+		{"missing mass", []string{"A"}, []string{"B"}, map[string]float64{"A": 1}},
+		{"non-positive mass", []string{"A"}, []string{"B"}, map[string]float64{"A": 1, "B": 0}},
+		{"no products", []string{"A"}, nil, map[string]float64{"A": 1}},
+	}
+	for _, tt := range tests {
+		if lhs, rhs, err := BalanceReaction(tt.reactants, tt.products, tt.masses); err == nil {
+			t.Errorf("%s: got %v -> %v, want an error", tt.name, lhs, rhs)
+		}
+	}
+}
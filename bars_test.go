@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func TestBarWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		logScale bool
+		want     int
+	}{
+		{"linear zero", 0, false, 0},
+		{"linear negative", -5, false, 0},
+		{"linear small", 30, false, 3},
+		{"linear below divisor", 9, false, 0},
+		{"linear capped", 100000, false, 500},
+		{"log zero", 0, true, 0},
+		{"log one", 1, true, 25}, // log10(2) of 6 decades
+		{"log small", 9, true, 500 / logBarDecades},
+		{"log capped", 1e9, true, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := barWidth(tt.count, 500, 10, tt.logScale); got != tt.want {
+				t.Errorf("barWidth(%d, 500, 10, %t) = %d, want %d", tt.count, tt.logScale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarWidthDivisor(t *testing.T) {
+	tests := []struct {
+		count, divisor, want int
+	}{
+		{1000, 1, 500}, // Capped
+		{400, 1, 400},
+		{1000, 5, 200},
+		{1000, 100, 10},
+		{99, 100, 0},
+		{1000, 0, 500}, // A non-positive divisor counts as 1
+		{300, -3, 300},
+		{1000000, 1000, 500},
+	}
+	for _, tt := range tests {
+		if got := barWidth(tt.count, 500, tt.divisor, false); got != tt.want {
+			t.Errorf("barWidth(%d, 500, %d, false) = %d, want %d", tt.count, tt.divisor, got, tt.want)
+		}
+	}
+}
+
+func TestBarDivisor(t *testing.T) {
+	g := NewGame()
+	g.BarDivisor = 7
+	if got := g.barDivisor(500); got != 7 {
+		t.Errorf("barDivisor() = %d with BarDivisor 7, want 7", got)
+	}
+
+	// Derived from the highest count seen, so the largest bar just fits
+	g.BarDivisor = 0
+	g.HighWater.Observe(map[string]int{"A": 120, "E": 2600})
+	if got := g.barDivisor(500); got != 6 {
+		t.Errorf("barDivisor() = %d for a peak of 2600, want 6", got)
+	}
+	if got := barWidth(2600, 500, g.barDivisor(500), false); got > 500 {
+		t.Errorf("peak bar %d pixels wide, over the 500 cap", got)
+	}
+}
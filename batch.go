@@ -0,0 +1,149 @@
+package main
+
+import "math"
+
+// --- Batched Fires ---
+
+// batchable reports whether Run may batch repeated selections. Selection
+// probabilities must stay fixed between steps, and a fire must not draw
+// from the main stream or depend on anything but the reaction's own counts.
+func (p *Pond) batchable() bool {
+	if !p.Batch || p.Gillespie || p.RateNoise > 0 || p.MutationRate > 0 || p.Lineage != nil ||
+		len(p.pending) > 0 || len(p.ProductionCaps) > 0 || len(p.MaxCount) > 0 || len(p.Reactions) == 0 ||
+		len(p.reactionIndex().volatile) > 0 {
+		return false
+	}
+	for _, r := range p.Reactions {
+		if r.CatalystDelay > 0 {
+			return false // Held-back catalysts are released step by step
+		}
+	}
+	return true
+}
+
+// runBatched performs n steps like Step would, statistically: each step
+// still selects a reaction with the same probability, but a run of
+// consecutive selections of one reaction is drawn at once (its length is
+// geometric) and its fires are applied together.
+func (p *Pond) runBatched(n int) {
+	for done := 0; done < n; {
+		idx := p.selectReaction()
+		if idx < 0 {
+			p.StepCount++
+			done++
+			continue
+		}
+
+		// Further selections of idx before another reaction is picked
+		repeats := min(p.repeatSelections(idx), n-done-1)
+		p.fireRepeated(idx, repeats+1)
+		done += repeats + 1
+		if done == n {
+			break
+		}
+
+		// The selection that ended the run is of some other reaction
+		p.StepCount++
+		done++
+		if other := p.selectOther(idx); other >= 0 && p.canFire(p.Reactions[other]) && p.succeeds(other) {
+			p.fire(other)
+		}
+	}
+}
+
+// repeatSelections draws how many times in a row idx is selected again:
+// geometric, with the probability a single step selects it.
+func (p *Pond) repeatSelections(idx int) int {
+	total := 0.0
+	for _, r := range p.Reactions {
+		total += p.InhibitedRate(r)
+	}
+	share := p.InhibitedRate(p.Reactions[idx]) / total
+	if share >= 1 {
+		return math.MaxInt32
+	}
+	return int(math.Log(1-p.rng.Float64()) / math.Log(share))
+}
+
+// selectOther picks a reaction other than idx in proportion to its rate, or
+// -1 when no other reaction can be selected.
+func (p *Pond) selectOther(idx int) int {
+	p.weights = p.weights[:0]
+	for i, r := range p.Reactions {
+		if i == idx {
+			p.weights = append(p.weights, 0)
+		} else {
+			p.weights = append(p.weights, p.InhibitedRate(r))
+		}
+	}
+	return p.pickWeighted(p.weights)
+}
+
+// fireRepeated makes n selected attempts of reaction idx, each a step. When
+// the reactants suffice for all of them and nothing about a fire is random,
+// they are applied at once; otherwise each is checked and fired in turn.
+func (p *Pond) fireRepeated(idx, n int) {
+	r := p.Reactions[idx]
+	if n > 1 && len(r.Branches) == 0 && (r.SuccessProbability <= 0 || r.SuccessProbability >= 1) &&
+		r.CatalystDelay <= 0 && !r.NeighborCatalyzed && p.maxFires(r) >= n {
+		p.applyBatch(idx, n)
+		return
+	}
+	for i := 0; i < n; i++ {
+		p.StepCount++
+		if p.canFire(r) && p.succeeds(idx) {
+			p.fire(idx)
+		}
+	}
+}
+
+// maxFires returns how many fires of r in a row its reactants allow while
+// its catalysts stay present, ignoring what its products replenish.
+func (p *Pond) maxFires(r Reaction) int {
+	fires := math.MaxInt
+	for k, reactant := range r.Reactants {
+		if countOf(r.Reactants[:k], reactant) > 0 {
+			continue // Counted at its first occurrence
+		}
+		available := p.Molecules[reactant]
+		if countOf(r.AllCatalysts(), reactant) > 0 {
+			available-- // One unit must remain to catalyze
+		}
+		fires = min(fires, max(available, 0)/countOf(r.Reactants, reactant))
+	}
+	for _, catalyst := range r.AllCatalysts() {
+		if p.catalystCount(r, catalyst) <= 0 {
+			return 0
+		}
+	}
+	return fires
+}
+
+// applyBatch applies n fires of reaction idx at once, each a step, with the
+// same effect on counts, statistics, the event log and fire observers as n
+// steps firing it. The reactants must suffice for all n. At debug level it
+// logs one line for the batch rather than one per fire.
+func (p *Pond) applyBatch(idx, n int) {
+	r := p.Reactions[idx]
+	for _, reactant := range r.Reactants {
+		p.Molecules[reactant] -= n
+	}
+	for _, product := range r.YieldedProducts() {
+		p.Molecules[product] += n
+	}
+	for i := 0; i < n; i++ {
+		p.StepCount++
+		p.recordFire(idx)
+		if p.Events != nil {
+			p.Events.record(p, idx, -1)
+		}
+		for _, fn := range p.fireObservers {
+			fn(idx, -1)
+		}
+	}
+	p.Status = ""
+	p.lastFire = fireEvent{Reaction: idx, Branch: -1, valid: true}
+	if logLevel >= LevelDebug {
+		logf(LevelDebug, "step %d: R%d fired %d times", p.StepCount, idx+1, n)
+	}
+}
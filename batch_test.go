@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// skewedPond returns a pond in which A -> B is selected three times as often
+// as A -> C, with A plentiful enough that neither runs out.
+func skewedPond(seed int64) *Pond {
+	p := testPond(seed, map[string]int{"A": 1e9, "B": 0, "C": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B", Rate: 3},
+		Reaction{Reactants: []string{"A"}, Product: "C", Rate: 1},
+	)
+	p.Batch = true
+	return p
+}
+
+func TestApplyBatchMatchesIndividualFires(t *testing.T) {
+	newPond := func() (*Pond, *[]int) {
+		p := testPond(1, map[string]int{"A": 100, "B": 7, "X": 0},
+			Reaction{Reactants: []string{"A", "B"}, Product: "B", ByProducts: []string{"X"}, ProductYield: 2},
+			Reaction{Reactants: []string{"X"}, Product: "A"},
+		)
+		var fired []int
+		p.AddFireObserver(func(reaction, branch int) { fired = append(fired, reaction) })
+		return p, &fired
+	}
+	const n = 40
+
+	batched, batchedFires := newPond()
+	batched.applyBatch(0, n)
+
+	single, singleFires := newPond()
+	for i := 0; i < n; i++ {
+		single.StepCount++
+		single.fire(0)
+	}
+
+	if !maps.Equal(batched.Molecules, single.Molecules) {
+		t.Errorf("batched counts %v, individual fires %v", batched.Molecules, single.Molecules)
+	}
+	if !slices.Equal(batched.FireCounts, single.FireCounts) {
+		t.Errorf("batched FireCounts %v, individual fires %v", batched.FireCounts, single.FireCounts)
+	}
+	if batched.StepCount != single.StepCount || batched.TotalFires() != n {
+		t.Errorf("batched steps %d fires %d, individual steps %d, want %d", batched.StepCount, batched.TotalFires(), single.StepCount, n)
+	}
+	if !slices.Equal(*batchedFires, *singleFires) {
+		t.Errorf("observers saw %d batched fires, %d individual", len(*batchedFires), len(*singleFires))
+	}
+	if batched.lastFire != single.lastFire {
+		t.Errorf("last fire: batched %+v, individual %+v", batched.lastFire, single.lastFire)
+	}
+}
+
+func TestApplyBatchStatsMatchIndividualFires(t *testing.T) {
+	newPond := func() *Pond {
+		p := testPond(1, map[string]int{"A": 30, "B": 0},
+			Reaction{Reactants: []string{"A"}, Product: "B"},
+			Reaction{Reactants: []string{"B"}, Product: "A"},
+		)
+		p.fire(0) // A fire before the window opens must not count
+		p.ResetStats()
+		return p
+	}
+	const n = 29
+
+	batched := newPond()
+	batched.applyBatch(0, n)
+	batched.notifyObservers(1)
+
+	single := newPond()
+	for i := 0; i < n; i++ {
+		single.StepCount++
+		single.fire(0)
+	}
+	single.notifyObservers(1)
+
+	if !slices.Equal(batched.FireCounts, single.FireCounts) || batched.FireCounts[0] != n {
+		t.Errorf("window fire counts: batched %v, individual fires %v, want %d fires of R1", batched.FireCounts, single.FireCounts, n)
+	}
+	if !maps.Equal(batched.MeanCounts(), single.MeanCounts()) {
+		t.Errorf("window means: batched %v, individual fires %v", batched.MeanCounts(), single.MeanCounts())
+	}
+	if !maps.Equal(batched.Extinctions(), single.Extinctions()) || batched.Extinctions()["A"] != 1 {
+		t.Errorf("window extinctions: batched %v, individual fires %v, want A once", batched.Extinctions(), single.Extinctions())
+	}
+}
+
+func TestApplyBatchLogsOneDebugLine(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	defer func(level LogLevel) { logLevel = level }(logLevel)
+	logLevel = LevelDebug
+
+	p := testPond(1, map[string]int{"A": 50}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	p.applyBatch(0, 40)
+	if got := strings.Count(buf.String(), "\n"); got != 1 || !strings.Contains(buf.String(), "step 40: R1 fired 40 times") {
+		t.Errorf("batch logged %d lines:\n%s\nwant one \"step 40: R1 fired 40 times\"", got, buf.String())
+	}
+}
+
+func TestRepeatSelectionsIsGeometric(t *testing.T) {
+	p := skewedPond(1)
+	const trials, share = 100000, 0.75
+	sum, zeros := 0, 0
+	for i := 0; i < trials; i++ {
+		k := p.repeatSelections(0)
+		sum += k
+		if k == 0 {
+			zeros++
+		}
+	}
+
+	// Mean share/(1-share) = 3, variance share/(1-share)^2 = 12
+	if mean := float64(sum) / trials; math.Abs(mean-3) > 5*math.Sqrt(12.0/trials) {
+		t.Errorf("mean repeats = %.3f, want 3", mean)
+	}
+	if got := float64(zeros) / trials; math.Abs(got-(1-share)) > 5*math.Sqrt(share*(1-share)/trials) {
+		t.Errorf("P(no repeat) = %.4f, want %.2f", got, 1-share)
+	}
+}
+
+func TestBatchedRunIsStatisticallyEquivalent(t *testing.T) {
+	const steps = 200000
+	for _, batch := range []bool{false, true} {
+		p := skewedPond(1)
+		p.Batch = batch
+		if batch && !p.batchable() {
+			t.Fatal("skewed pond not batchable")
+		}
+
+		// Runs of consecutive A -> B fires have mean length 1/(1-0.75) = 4
+		var runs, inRun int
+		last := -1
+		p.AddFireObserver(func(reaction, branch int) {
+			if reaction == 0 && last != 0 {
+				runs++
+			}
+			if reaction == 0 {
+				inRun++
+			}
+			last = reaction
+		})
+		p.Run(steps)
+
+		if p.StepCount != steps || p.TotalFires() != steps {
+			t.Fatalf("batch=%v: %d steps and %d fires, want %d", batch, p.StepCount, p.TotalFires(), steps)
+		}
+		if share := float64(p.Molecules["B"]) / steps; math.Abs(share-0.75) > 5*math.Sqrt(0.75*0.25/steps) {
+			t.Errorf("batch=%v: A -> B share %.4f, want 0.75", batch, share)
+		}
+		if mean := float64(inRun) / float64(runs); math.Abs(mean-4) > 0.1 {
+			t.Errorf("batch=%v: mean run of A -> B = %.3f, want 4", batch, mean)
+		}
+	}
+}
+
+func BenchmarkRunBatched(b *testing.B) {
+	for _, batch := range []bool{false, true} {
+		name := "single"
+		if batch {
+			name = "batched"
+		}
+		b.Run(name, func(b *testing.B) {
+			p := testPond(benchSeed, map[string]int{"A": 1e9, "B": 1e9},
+				Reaction{Reactants: []string{"A"}, Product: "B", Rate: 99},
+				Reaction{Reactants: []string{"B"}, Product: "A", Rate: 1},
+			)
+			p.Batch = batch
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				p.Run(DefaultStepsPerTick)
+			}
+		})
+	}
+}
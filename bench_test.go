@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// benchSeed fixes every benchmark's trajectory so results compare across commits.
+const benchSeed = 1
+
+// randomNetwork returns a pond of n random reactions over 20 well-stocked species.
+func randomNetwork(b *testing.B, n int) *Pond {
+	b.Helper()
+	molecules := make([]Molecule, 20)
+	counts := make(map[string]int, len(molecules))
+	for i := range molecules {
+		molecules[i].Name = fmt.Sprintf("S%d", i)
+		counts[molecules[i].Name] = 1_000_000
+	}
+	reactions, err := GenerateRandomReactions(molecules, n, rand.New(rand.NewSource(benchSeed)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return testPond(benchSeed, counts, reactions...)
+}
+
+// benchmarkSteps steps p b.N times, outside any GUI.
+func benchmarkSteps(b *testing.B, p *Pond) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Step()
+	}
+}
+
+func BenchmarkStep(b *testing.B) {
+	p := NewPondWithSeed(benchSeed)
+	p.Molecules["A"], p.Molecules["B"], p.Molecules["C"] = 1_000_000, 1_000_000, 1_000_000
+	benchmarkSteps(b, p)
+}
+
+func BenchmarkStepGillespie(b *testing.B) {
+	p := NewPondWithSeed(benchSeed)
+	p.Molecules["A"], p.Molecules["B"], p.Molecules["C"] = 1_000_000, 1_000_000, 1_000_000
+	p.Gillespie = true
+	benchmarkSteps(b, p)
+}
+
+func BenchmarkStepNReactions(b *testing.B) {
+	for _, n := range []int{4, 16, 64, 256, 1024} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			benchmarkSteps(b, randomNetwork(b, n))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBranchFrequencies(t *testing.T) {
+	const fires = 20000
+	// Weights 7:3 are normalized to 70% and 30%
+	r := Reaction{Reactants: []string{"D"}, Branches: []Branch{
+		{Products: []string{"E"}, Probability: 7},
+		{Products: []string{"A", "B"}, Probability: 3},
+	}}
+	p := testPond(1, map[string]int{"D": fires, "E": 0, "A": 0, "B": 0}, r)
+	for p.Molecules["D"] > 0 {
+		p.Step()
+	}
+
+	if p.Molecules["A"] != p.Molecules["B"] {
+		t.Errorf("branch products out of step: A = %d, B = %d", p.Molecules["A"], p.Molecules["B"])
+	}
+	if total := p.Molecules["E"] + p.Molecules["A"]; total != fires {
+		t.Fatalf("%d branch outcomes for %d fires", total, fires)
+	}
+	if share := float64(p.Molecules["E"]) / fires; math.Abs(share-0.7) > 0.02 {
+		t.Errorf("first branch taken %.3f of the time, want 0.7 ± 0.02", share)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+// --- Production Caps ---
+
+// withinCaps reports whether firing r keeps every capped species within its
+// per-tick production limit (enzyme saturation). A branching reaction is
+// checked against the products of all its branches, as the branch is only
+// drawn once it fires.
+func (p *Pond) withinCaps(r Reaction) bool {
+	if len(p.ProductionCaps) == 0 {
+		return true
+	}
+	products := r.AllProducts()
+	if len(r.Branches) == 0 {
+		products = r.YieldedProducts()
+	}
+	for k, product := range products {
+		limit, ok := p.ProductionCaps[product]
+		if ok && p.produced[product]+countOf(products[:k+1], product) > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// producesCapped reports whether any product of r, in any branch, has a
+// production cap. Such a reaction must fire one unit at a time.
+func (p *Pond) producesCapped(r Reaction) bool {
+	for _, product := range r.AllProducts() {
+		if _, ok := p.ProductionCaps[product]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// belowMax reports whether firing r keeps every species with a MaxCount at
+// or below it. Only a net increase is checked, so a reaction that consumes
+// as much of a species as it makes is never blocked by it; branching
+// reactions are checked against the products of all their branches.
+func (p *Pond) belowMax(r Reaction) bool {
+	if len(p.MaxCount) == 0 {
+		return true
+	}
+	products := r.AllProducts()
+	if len(r.Branches) == 0 {
+		products = r.YieldedProducts()
+	}
+	for _, product := range products {
+		limit, ok := p.MaxCount[product]
+		if !ok {
+			continue
+		}
+		if gain := countOf(products, product) - countOf(r.Reactants, product); gain > 0 && p.Molecules[product]+gain > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// countProduced records one unit of species made during the current tick.
+func (p *Pond) countProduced(species string) {
+	if len(p.ProductionCaps) == 0 {
+		return
+	}
+	if p.produced == nil {
+		p.produced = map[string]int{}
+	}
+	p.produced[species]++
+}
+
+// ProducedThisTick returns how many units of species have been made since
+// the current tick began.
+func (p *Pond) ProducedThisTick(species string) int {
+	return p.produced[species]
+}
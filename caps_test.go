@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+// capPond returns a pond in which A -> B would make far more than five B a
+// tick, with B capped at five.
+func capPond() *Pond {
+	p := testPond(1, map[string]int{"A": 1e6, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"})
+	p.ProductionCaps = map[string]int{"B": 5}
+	return p
+}
+
+func TestProductionCapHoldsEveryTick(t *testing.T) {
+	for _, mode := range []string{"step", "gillespie", "ode", "hybrid"} {
+		t.Run(mode, func(t *testing.T) {
+			p := capPond()
+			switch mode {
+			case "gillespie":
+				p.Gillespie = true
+			case "ode":
+				p.ODEThreshold = 1000
+			case "hybrid":
+				p.ODEThreshold = 1000
+				p.Hybrid = true
+			}
+			for tick := 0; tick < 200; tick++ {
+				before := p.Molecules["B"]
+				p.Run(100)
+				if gain := p.Molecules["B"] - before; gain != 5 {
+					t.Fatalf("tick %d: B gained %d, want the cap of 5", tick, gain)
+				}
+				if got := p.ProducedThisTick("B"); got != 5 {
+					t.Fatalf("tick %d: ProducedThisTick = %d, want 5", tick, got)
+				}
+			}
+			if p.Amounts != nil && mode == "ode" {
+				t.Errorf("capped network was integrated: Amounts = %v", p.Amounts)
+			}
+		})
+	}
+}
+
+func TestProducesCapped(t *testing.T) {
+	p := capPond()
+	if !p.producesCapped(p.Reactions[0]) {
+		t.Error("A -> B not reported as producing capped B")
+	}
+	branching := Reaction{Reactants: []string{"A"}, Branches: []Branch{{Products: []string{"C"}}, {Products: []string{"B"}}}}
+	if !p.producesCapped(branching) {
+		t.Error("branch making B not reported as producing capped B")
+	}
+	if p.producesCapped(Reaction{Reactants: []string{"B"}, Product: "A"}) {
+		t.Error("B -> A reported as producing a capped species")
+	}
+}
+
+func TestHybridKeepsUncappedReactionsFast(t *testing.T) {
+	p := capPond()
+	p.Molecules["C"], p.Molecules["D"] = 1e6, 1e6
+	p.Reactions = append(p.Reactions, Reaction{Reactants: []string{"C"}, Product: "D"})
+	p.ODEThreshold = 1000
+	p.Hybrid = true
+	if fast := p.fastReactions(); fast[0] || !fast[1] {
+		t.Errorf("fastReactions() = %v, want only C -> D fast", fast)
+	}
+}
+
+func TestGillespieSpendsCappedStepsElsewhere(t *testing.T) {
+	// Two reactions make capped B; once either reaches the cap, every further
+	// step must go to the uncapped A -> C
+	p := capPond()
+	p.Molecules["D"] = 1e6
+	p.Reactions = append(p.Reactions,
+		Reaction{Reactants: []string{"D"}, Product: "B"},
+		Reaction{Reactants: []string{"A"}, Product: "C"})
+	p.Gillespie = true
+	for tick := 1; tick <= 20; tick++ {
+		p.Run(100)
+		if got, want := p.Molecules["B"], 5*tick; got != want {
+			t.Fatalf("tick %d: B = %d, want %d", tick, got, want)
+		}
+		if got, want := p.TotalFires(), 100*tick; got != want {
+			t.Fatalf("tick %d: %d fires, want one every step (%d)", tick, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestMultiCatalystReactionNeedsAllCatalysts(t *testing.T) {
+	r := Reaction{Reactants: []string{"A"}, Product: "B", Catalysts: []string{"X", "Y"}}
+	tests := []struct {
+		name  string
+		x, y  int
+		fires bool
+	}{
+		{"both present", 1, 1, true},
+		{"X absent", 0, 1, false},
+		{"Y absent", 1, 0, false},
+		{"both absent", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPond(1, map[string]int{"A": 10, "B": 0, "X": tt.x, "Y": tt.y}, r)
+			for i := 0; i < 5; i++ {
+				p.Step()
+			}
+			if fired := p.Molecules["B"] > 0; fired != tt.fires {
+				t.Errorf("fired = %t, want %t (B = %d)", fired, tt.fires, p.Molecules["B"])
+			}
+			if p.Molecules["X"] != tt.x || p.Molecules["Y"] != tt.y {
+				t.Errorf("catalysts consumed: X = %d, Y = %d", p.Molecules["X"], p.Molecules["Y"])
+			}
+		})
+	}
+}
+
+func TestAutocatalysisWithSeveralCatalysts(t *testing.T) {
+	r := Reaction{Reactants: []string{"A"}, Product: "E", Catalysts: []string{"X", "E"}}
+	if !r.IsAutocatalytic() {
+		t.Error("reaction whose product is one of its catalysts is not autocatalytic")
+	}
+}
+
+func TestLegacyCatalystFieldIsRequired(t *testing.T) {
+	r := Reaction{Reactants: []string{"A"}, Product: "B", Catalyst: "X", Catalysts: []string{"Y"}}
+	p := testPond(1, map[string]int{"A": 10, "X": 0, "Y": 1}, r)
+	for i := 0; i < 5; i++ {
+		p.Step()
+	}
+	if p.Molecules["B"] != 0 {
+		t.Errorf("fired without the legacy catalyst X: B = %d", p.Molecules["B"])
+	}
+}
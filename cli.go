@@ -0,0 +1,664 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Command Line ---
+
+// A command is one subcommand of the program, with its own flag set.
+type command struct {
+	Name    string
+	Summary string
+	Run     func(args []string) error
+}
+
+// commands lists the subcommands; the first one is the default.
+var commands = []command{
+	{Name: "run", Summary: "open the simulation window (default)", Run: runCommand},
+	{Name: "headless", Summary: "run a bounded simulation without a window", Run: headlessCommand},
+	{Name: "sweep", Summary: "run one trial per value of a reaction rate", Run: sweepCommand},
+	{Name: "sensitivity", Summary: "rank reactions by how their rates shift emergence", Run: sensitivityCommand},
+	{Name: "validate", Summary: "check config files and report warnings", Run: validateCommand},
+	{Name: "replay", Summary: "continue a saved snapshot without a window", Run: replayCommand},
+	{Name: "diff", Summary: "compare two snapshot files", Run: diffCommand},
+	{Name: "dot", Summary: "export the reaction network as Graphviz DOT", Run: dotCommand},
+	{Name: "matrix", Summary: "print the stoichiometric matrix as CSV", Run: matrixCommand},
+	{Name: "raf", Summary: "find the maximal RAF set of reactions for a food set", Run: rafCommand},
+	{Name: "compete", Summary: "race replicators with different autocatalytic rates", Run: competeCommand},
+	{Name: "protocells", Summary: "run a dividing population of compartments", Run: protocellsCommand},
+	{Name: "polymer", Summary: "run the polymer-world chemistry without a window", Run: polymerCommand},
+	{Name: "gif", Summary: "export the bar chart over a tick range as an animated GIF", Run: gifCommand},
+	{Name: "grid", Summary: "open a spatial grid and pour molecules into cells", Run: gridCommand},
+}
+
+// findCommand picks the subcommand named by the first argument and returns it
+// with the remaining arguments. With no arguments, or when the first argument
+// is a flag, the default command receives all of them.
+func findCommand(args []string) (*command, []string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return &commands[0], args, nil
+	}
+	for i := range commands {
+		if commands[i].Name == args[0] {
+			return &commands[i], args[1:], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unknown command %q", args[0])
+}
+
+// usage lists the available subcommands.
+func usage() string {
+	var b strings.Builder
+	b.WriteString("Usage: abiogenesis [command] [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(&b, "  %-11s %s\n", c.Name, c.Summary)
+	}
+	return b.String()
+}
+
+// runOptions are the flags shared by the commands that build and run a Game.
+type runOptions struct {
+	flags             *flag.FlagSet // The set registered on, to tell given flags from defaults
+	configPath        string
+	saveConfigPath    string
+	lineage           bool
+	checkpointEvery   int
+	checkpointPath    string
+	resume            bool
+	controlTarget     int
+	controlGain       float64
+	controlReaction   int
+	knockdownFraction float64
+	rateNoise         float64
+	gillespie         bool
+	screenshot        bool
+	logLevel          LogLevel
+	countsPath        string
+	mutationRate      float64
+	mutationSpread    float64
+	renderEvery       int
+	odeThreshold      int
+	hybrid            bool
+	theme             string
+	eventsPath        string
+	runMetadata       bool
+	messagesPath      string
+	kickstart         Kickstart
+	barDivisor        int
+	antiAlias         bool
+	setup             bool
+	timeStep          float64
+	batch             bool
+	emergence         EmergenceCondition
+	httpAddr          string
+	guarded           bool
+	resetStatsAt      int
+}
+
+// register adds the shared flags to fs.
+func (o *runOptions) register(fs *flag.FlagSet) {
+	o.flags = fs
+	fs.StringVar(&o.configPath, "config", "", "Load the experiment (molecules, reactions, parameters) from a JSON or YAML file")
+	fs.StringVar(&o.saveConfigPath, "save-config", "", "Where the S key saves the config (default: the -config file, else config.json)")
+	fs.BoolVar(&o.lineage, "lineage", false, "Track which reaction produced each molecule (slow)")
+	fs.IntVar(&o.checkpointEvery, "checkpoint-every", 0, "Save a checkpoint every N ticks (0 disables)")
+	fs.StringVar(&o.checkpointPath, "checkpoint", "checkpoint.json", "Base path for rotating checkpoint files")
+	fs.StringVar(&o.countsPath, "counts", "", "Warm start: replace the initial counts with those in this JSON file")
+	fs.BoolVar(&o.resume, "resume", false, "Resume from the most recent valid checkpoint")
+	fs.IntVar(&o.controlTarget, "control-target", 0, "Regulate E towards this count by adjusting a degradation rate (0 disables)")
+	fs.Float64Var(&o.controlGain, "control-gain", 0.0005, "Proportional gain of the rate controller")
+	fs.IntVar(&o.controlReaction, "control-reaction", 4, "Reaction number (1-based) whose rate the controller adjusts")
+	fs.Float64Var(&o.mutationRate, "mutation-rate", 0, "Probability that an autocatalytic copy is a new replicator with a mutated rate")
+	fs.Float64Var(&o.mutationSpread, "mutation-spread", DefaultMutationSpread, "Standard deviation of a mutant's log rate change")
+	fs.Float64Var(&o.rateNoise, "rate-noise", 0, "Standard deviation of per-step multiplicative noise on reaction rates")
+	fs.IntVar(&o.odeThreshold, "ode-threshold", 0, "Integrate rate equations deterministically while every reactant has at least this many molecules (0 disables)")
+	fs.BoolVar(&o.hybrid, "hybrid", false, "With -ode-threshold, integrate only the reactions among abundant species and fire the rest stochastically")
+	fs.TextVar(&o.kickstart, "kickstart", Kickstart{}, "Bootstrap autocatalysis with species:amount instead of the default E:1 (E:0 for none); with -config, sets that count")
+	fs.BoolVar(&o.guarded, "guarded", false, "Refuse and log any fire that would drive a count negative")
+	fs.BoolVar(&o.batch, "batch", false, "Apply runs of repeated selections of one reaction at once (faster; same statistics, different random sequence)")
+	fs.BoolVar(&o.gillespie, "gillespie", false, "Select reactions by propensity in continuous time (Gillespie's algorithm)")
+	fs.TextVar(&o.logLevel, "v", LevelInfo, "Log verbosity on stderr: error, warn, info or debug")
+	fs.IntVar(&o.barDivisor, "bar-divisor", DefaultBarDivisor, "Molecules per pixel of a linear count bar (0 scales to the highest count seen)")
+	fs.Float64Var(&o.timeStep, "time-step", DefaultTimeStep, "Simulated seconds per tick, shown on the status line when toggled to time")
+	fs.BoolVar(&o.setup, "setup", false, "Start paused on a setup screen to adjust the starting counts before the first step")
+	fs.BoolVar(&o.antiAlias, "antialias", false, "Draw bars and graph lines anti-aliased, for smoother screenshots")
+	fs.IntVar(&o.resetStatsAt, "reset-stats-at", 0, "Reset fire counts, means and extinction counts at this tick, leaving out the transient (the R key resets them any time)")
+	fs.IntVar(&o.renderEvery, "render-every", 1, "Draw a frame and sample the graph history only every N ticks")
+	fs.StringVar(&o.theme, "theme", DefaultTheme, "Color theme: default, colorblind or high-contrast (the T key cycles them)")
+	fs.StringVar(&o.messagesPath, "messages", "", "Override UI messages with those in this JSON object (e.g. a translation)")
+	fs.StringVar(&o.httpAddr, "http", "", "Serve the current state at /state and Prometheus metrics at /metrics on this address (e.g. :8080)")
+	fs.StringVar(&o.eventsPath, "events", "", "Write every successful fire as a JSON line to this file")
+	fs.BoolVar(&o.runMetadata, "run-metadata", true, "With -events, also write the seed, config and version to a .meta.json file beside the log")
+	fs.TextVar(&o.emergence, "emergence", EmergenceCondition{}, "Emergence condition over species counts, e.g. \"E > 1000 AND D > 100\" (default: E above the threshold)")
+	fs.BoolVar(&o.screenshot, "emergence-screenshot", false, "Save emergence_tick_N.png when emergence is first reached")
+	fs.Float64Var(&o.knockdownFraction, "knockdown", DefaultKnockdownFraction, "Fraction of the focused species removed by the K key")
+}
+
+// config returns the loaded config, or the default chemistry when none was given.
+func (o *runOptions) config() (*Config, error) {
+	if o.configPath == "" {
+		return DefaultConfig(), nil
+	}
+	return LoadConfig(o.configPath)
+}
+
+// given reports whether the named flag was set on the command line.
+func (o *runOptions) given(name string) bool {
+	given := false
+	if o.flags != nil {
+		o.flags.Visit(func(f *flag.Flag) { given = given || f.Name == name })
+	}
+	return given
+}
+
+// newGame builds the Game described by the options.
+func (o *runOptions) newGame() (*Game, error) {
+	if o.messagesPath != "" {
+		overrides, err := LoadMessages(o.messagesPath)
+		if err != nil {
+			return nil, err
+		}
+		SetMessages(overrides) // Before any pond is built, so the initial status uses them
+	}
+	kick := DefaultKickstart
+	if o.kickstart.Species != "" {
+		kick = o.kickstart
+	}
+	game := newGameWithPond(NewPondWithKickstart(time.Now().UnixNano(), kick))
+	if o.configPath != "" {
+		cfg, err := LoadConfig(o.configPath)
+		if err != nil {
+			return nil, err
+		}
+		game = cfg.NewGame()
+		o.kickstart.Apply(game.Pond.Molecules)
+	}
+	if o.resume {
+		snap, err := LoadLatestCheckpoint(o.checkpointPath)
+		if err != nil {
+			return nil, err
+		}
+		game = snap.NewGame()
+	}
+	if o.countsPath != "" {
+		counts, err := LoadCounts(o.countsPath)
+		if err != nil {
+			return nil, err
+		}
+		game.Pond.Molecules = counts
+	}
+	o.apply(game)
+	if o.eventsPath != "" {
+		events, err := CreateEventLog(o.eventsPath)
+		if err != nil {
+			return nil, err
+		}
+		game.LogEvents(events)
+		if o.runMetadata {
+			meta, err := NewRunMetadata(game, o.configPath, time.Now())
+			if err == nil {
+				err = WriteRunMetadata(o.eventsPath, meta)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("writing run metadata: %w", err)
+			}
+		}
+	}
+	if o.httpAddr != "" {
+		if err := game.Serve(o.httpAddr); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// apply sets the per-run options on an already constructed Game.
+func (o *runOptions) apply(game *Game) {
+	if o.controlTarget > 0 {
+		game.Pond.Controller = &RateController{Species: "E", Target: o.controlTarget, Gain: o.controlGain, Reaction: o.controlReaction - 1}
+	}
+	// The config's settings stand unless a flag is given
+	if o.given("rate-noise") {
+		game.Pond.RateNoise = o.rateNoise
+	}
+	if o.given("mutation-rate") {
+		game.Pond.MutationRate = o.mutationRate
+	}
+	if o.given("mutation-spread") {
+		game.Pond.MutationSpread = o.mutationSpread
+	}
+	if o.given("gillespie") {
+		game.Pond.Gillespie = o.gillespie
+	}
+	if o.given("guarded") {
+		game.Pond.Guarded = o.guarded
+	}
+	if o.given("batch") {
+		game.Pond.Batch = o.batch
+	}
+	if o.given("ode-threshold") {
+		game.Pond.ODEThreshold = o.odeThreshold
+	}
+	if o.given("hybrid") {
+		game.Pond.Hybrid = o.hybrid
+	}
+	game.ConfigPath = o.saveConfigPath
+	if game.ConfigPath == "" {
+		game.ConfigPath = o.configPath
+	}
+	if game.ConfigPath == "" {
+		game.ConfigPath = "config.json"
+	}
+	game.KnockdownFraction = o.knockdownFraction
+	game.CheckpointEvery = o.checkpointEvery
+	game.CheckpointPath = o.checkpointPath
+	game.ScreenshotOnEmergence = o.screenshot
+	game.RenderEvery = o.renderEvery
+	if o.given("bar-divisor") {
+		game.BarDivisor = o.barDivisor
+	}
+	game.AntiAlias = o.antiAlias
+	game.Setup = o.setup
+	if o.given("time-step") {
+		game.TimeStep = o.timeStep
+	}
+	if o.emergence.String() != "" {
+		game.Emergence = &o.emergence
+	}
+	game.ResetStatsAt = o.resetStatsAt
+	theme, ok := ThemeByName(o.theme)
+	if !ok {
+		logf(LevelWarn, "unknown theme %q, using %s", o.theme, DefaultTheme)
+	}
+	game.Theme = theme
+	logLevel = o.logLevel
+	if o.lineage {
+		game.Pond.EnableLineage()
+	}
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var opts runOptions
+	opts.register(fs)
+	fs.Parse(args)
+
+	game, err := opts.newGame()
+	if err != nil {
+		return err
+	}
+	err = runGUI(game)
+	if cerr := game.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func headlessCommand(args []string) error {
+	fs := flag.NewFlagSet("headless", flag.ExitOnError)
+	var opts runOptions
+	opts.register(fs)
+	steps := fs.Int("steps", 1000000, "Number of simulation steps")
+	quiet := fs.Bool("quiet", false, "Suppress the startup summary and progress output")
+	trials := fs.Int("trials", 0, "Run K independent trials concurrently and report their final counts")
+	minCoverage := fs.Float64("min-coverage", 0, "Fail unless at least this percentage of reactions fired (for CI)")
+	untilExtinct := fs.String("until-extinct", "", "Stop once this species' count has stayed at 0 for the grace period")
+	extinctGrace := fs.Int("extinct-grace", DefaultExtinctGrace, "Ticks a species must stay at 0 to count as extinct")
+	fs.Parse(args)
+
+	if *trials > 0 {
+		cfg, err := opts.config()
+		if err != nil {
+			return err
+		}
+		fmt.Print(FormatTrials(RunTrials(cfg, *trials, *steps)))
+		return nil
+	}
+
+	game, err := opts.newGame()
+	if err != nil {
+		return err
+	}
+	var watch *extinctionWatch
+	if *untilExtinct != "" {
+		if watch, err = newExtinctionWatch(game.Pond, *untilExtinct, *extinctGrace); err != nil {
+			return err
+		}
+	}
+	runHeadless(game, *steps, *quiet, watch)
+	if err := game.Close(); err != nil {
+		return err
+	}
+	if coverage := game.Pond.Coverage(); coverage < *minCoverage {
+		return fmt.Errorf("reaction coverage %.1f%% is below the required %.1f%% (never fired: %s)",
+			coverage, *minCoverage, reactionLabels(game.Pond.DeadReactions()))
+	}
+	return nil
+}
+
+func sweepCommand(args []string) error {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	reaction := fs.Int("reaction", 3, "Reaction number (1-based) whose rate is swept")
+	from := fs.Float64("from", 0.5, "First rate value")
+	to := fs.Float64("to", 2, "Last rate value")
+	n := fs.Int("n", 5, "Number of rate values")
+	steps := fs.Int("steps", 100000, "Steps per trial")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	if *reaction < 1 || *reaction > len(cfg.Reactions) {
+		return fmt.Errorf("reaction %d out of range (1-%d)", *reaction, len(cfg.Reactions))
+	}
+	if *n < 1 {
+		return fmt.Errorf("n must be at least 1")
+	}
+
+	for i := 0; i < *n; i++ {
+		rate := *from
+		if *n > 1 {
+			rate += (*to - *from) * float64(i) / float64(*n-1)
+		}
+		trialCfg := *cfg
+		trialCfg.Reactions = append([]Reaction(nil), cfg.Reactions...)
+		trialCfg.Reactions[*reaction-1].Rate = rate
+
+		res := RunTrials(&trialCfg, 1, *steps)[0]
+		fmt.Printf("R%d rate %.4g:", *reaction, rate)
+		for _, name := range (&Pond{Molecules: res.Counts}).SpeciesNames() {
+			fmt.Printf(" %s=%d", name, res.Counts[name])
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func sensitivityCommand(args []string) error {
+	fs := flag.NewFlagSet("sensitivity", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	trials := fs.Int("trials", 5, "Fixed-seed trials per rate setting")
+	steps := fs.Int("steps", 200000, "Maximum steps per trial")
+	seed := fs.Int64("seed", 1, "Seed of the first trial")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	if *trials < 1 {
+		return fmt.Errorf("trials must be at least 1")
+	}
+	cfg.Seed = *seed
+	logLevel = LevelWarn // Per-trial emergence and extinction alerts would bury the table
+	fmt.Print(FormatSensitivity(SensitivityAnalysis(cfg, *trials, *steps)))
+	return nil
+}
+
+func validateCommand(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return fmt.Errorf("validate: no config files given")
+	}
+
+	failed := 0
+	for _, path := range fs.Args() {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			fmt.Println(err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: ok\n", path)
+		fmt.Print(startupBanner(cfg.NewGame(), "E"))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d configs invalid", failed, fs.NArg())
+	}
+	return nil
+}
+
+func replayCommand(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	steps := fs.Int("steps", 1000000, "Number of simulation steps to run from the snapshot")
+	quiet := fs.Bool("quiet", false, "Suppress the startup summary and progress output")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("replay: expected one snapshot file")
+	}
+
+	snap, err := LoadSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	runHeadless(snap.NewGame(), *steps, *quiet, nil)
+	return nil
+}
+
+func diffCommand(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("diff: expected two snapshot files")
+	}
+
+	a, err := LoadSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := LoadSnapshot(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	fmt.Print(DiffSnapshots(a, b))
+	return nil
+}
+
+func dotCommand(args []string) error {
+	fs := flag.NewFlagSet("dot", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	out := fs.String("o", "", "Write to this file instead of standard output")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	dot := cfg.NewGame().Pond.DOT()
+	if *out == "" {
+		fmt.Print(dot)
+		return nil
+	}
+	return os.WriteFile(*out, []byte(dot), 0o644)
+}
+
+func matrixCommand(args []string) error {
+	fs := flag.NewFlagSet("matrix", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	fmt.Print(FormatStoichiometryCSV(cfg.NewGame().Pond.StoichiometryMatrix()))
+	return nil
+}
+
+func rafCommand(args []string) error {
+	fs := flag.NewFlagSet("raf", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	foodSpec := fs.String("food", "", "Comma-separated food species (default: every species present at the start)")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	p := cfg.NewGame().Pond
+	var food []string
+	if *foodSpec == "" {
+		for _, name := range p.SpeciesNames() {
+			if p.Molecules[name] > 0 {
+				food = append(food, name)
+			}
+		}
+	} else {
+		for _, name := range strings.Split(*foodSpec, ",") {
+			food = append(food, strings.TrimSpace(name))
+		}
+	}
+
+	raf := p.FindRAF(food)
+	fmt.Printf("Food set: %s\n", strings.Join(food, ", "))
+	fmt.Printf("Maximal RAF: %s\n", reactionLabels(raf))
+	for _, i := range raf {
+		fmt.Printf("  R%d: %s\n", i+1, p.Reactions[i])
+	}
+	return nil
+}
+
+func polymerCommand(args []string) error {
+	fs := flag.NewFlagSet("polymer", flag.ExitOnError)
+	monomers := fs.String("monomers", "AB", "Monomer letters to start from")
+	count := fs.Int("count", 200, "Initial count of each monomer")
+	steps := fs.Int("steps", 1000000, "Number of simulation steps to run")
+	seed := fs.Int64("seed", 0, "Random seed")
+	maxLength := fs.Int("max-length", DefaultMaxPolymerLength, "Longest polymer ligation may build")
+	fs.Parse(args)
+
+	initial := make(map[string]int)
+	for _, m := range *monomers {
+		initial[string(m)] = *count
+	}
+	pp, err := NewPolymerPond(initial, *seed)
+	if err != nil {
+		return err
+	}
+	pp.MaxLength = *maxLength
+	pp.Run(*steps)
+
+	fmt.Printf("%d species, %d reactions after %d steps\n", len(pp.Molecules), len(pp.Reactions), *steps)
+	printCounts(pp.Pond)
+	return nil
+}
+
+func protocellsCommand(args []string) error {
+	fs := flag.NewFlagSet("protocells", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty)")
+	n := fs.Int("compartments", 8, "Initial number of compartments")
+	ticks := fs.Int("ticks", 200, "Ticks to run")
+	feedSpec := fs.String("feed", "A=20,B=20,C=20", "Food taken up by each compartment per tick, as species=amount pairs")
+	divisionSize := fs.Int("division-size", DefaultDivisionSize, "Total molecules at which a compartment divides")
+	maxCompartments := fs.Int("max", DefaultMaxCompartments, "Population cap")
+	fs.Parse(args)
+
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	feed, err := ParseFeed(*feedSpec)
+	if err != nil {
+		return err
+	}
+
+	cp := NewCompartmentPopulation(cfg, *n)
+	cp.Feed = feed
+	cp.DivisionSize = *divisionSize
+	cp.MaxCompartments = *maxCompartments
+	for t := 1; t <= *ticks; t++ {
+		cp.Run(cfg.StepsPerTick)
+		if t%10 == 0 || t == *ticks {
+			fmt.Printf("tick %d: %s\n", t, cp.PopulationStats())
+		}
+	}
+	fmt.Print(FormatSizeHistogram(cp.SizeHistogram(SizeHistogramBins), cp.DivisionSize))
+	return nil
+}
+
+func competeCommand(args []string) error {
+	fs := flag.NewFlagSet("compete", flag.ExitOnError)
+	ratesSpec := fs.String("rates", "1,1.2", "Comma-separated autocatalytic rates, one per replicator")
+	steps := fs.Int("steps", 1000000, "Number of simulation steps")
+	seed := fs.Int64("seed", 0, "Random seed")
+	quiet := fs.Bool("quiet", false, "Suppress the startup summary and progress output")
+	fs.Parse(args)
+
+	var rates []float64
+	for _, field := range strings.Split(*ratesSpec, ",") {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || rate <= 0 {
+			return fmt.Errorf("compete: invalid rate %q", field)
+		}
+		rates = append(rates, rate)
+	}
+
+	cfg := CompetitionConfig(rates)
+	cfg.Seed = *seed
+	game := cfg.NewGame()
+	game.Pond.Gillespie = true
+	runHeadless(game, *steps, *quiet, nil)
+	return game.Close()
+}
+
+func gridCommand(args []string) error {
+	fs := flag.NewFlagSet("grid", flag.ExitOnError)
+	configPath := fs.String("config", "", "Experiment config (default chemistry if empty); every cell starts with its counts")
+	width := fs.Int("width", 16, "Grid width in cells")
+	height := fs.Int("height", 12, "Grid height in cells")
+	species := fs.String("species", "E", "Species shown and poured at start (Tab cycles)")
+	pour := fs.Int("pour", DefaultPourAmount, "Molecules poured into a cell per click")
+	fs.Parse(args)
+
+	if *width < 1 || *height < 1 {
+		return fmt.Errorf("grid size %dx%d must be at least 1x1", *width, *height)
+	}
+	opts := runOptions{configPath: *configPath}
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	game := NewGridGame(cfg, *width, *height)
+	game.PourAmount = *pour
+	if i := slices.Index(game.Species, *species); i >= 0 {
+		game.Selected = i
+	}
+	return runGridGUI(game)
+}
+
+func gifCommand(args []string) error {
+	fs := flag.NewFlagSet("gif", flag.ExitOnError)
+	var opts runOptions
+	opts.register(fs)
+	out := fs.String("out", "run.gif", "GIF file to write")
+	from := fs.Int("from", 0, "First tick to record")
+	to := fs.Int("to", 500, "Last tick to record")
+	every := fs.Int("every", 5, "Ticks between frames")
+	fs.Parse(args)
+
+	game, err := opts.newGame()
+	if err != nil {
+		return err
+	}
+	if err := WriteGIF(*out, game, *from, *to, *every); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s (ticks %d-%d)\n", *out, *from, game.TickCounter)
+	return game.Close()
+}
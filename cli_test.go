@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		handler func([]string) error
+		rest    []string
+	}{
+		{nil, runCommand, nil},
+		{[]string{"-config", "x.json"}, runCommand, []string{"-config", "x.json"}},
+		{[]string{"run", "-seed", "1"}, runCommand, []string{"-seed", "1"}},
+		{[]string{"headless", "-steps", "5"}, headlessCommand, []string{"-steps", "5"}},
+		{[]string{"sweep"}, sweepCommand, []string{}},
+		{[]string{"validate", "a.json"}, validateCommand, []string{"a.json"}},
+		{[]string{"replay", "-snapshot", "s.json"}, replayCommand, []string{"-snapshot", "s.json"}},
+	}
+	for _, tt := range tests {
+		c, rest, err := findCommand(tt.args)
+		if err != nil {
+			t.Errorf("findCommand(%q): %v", tt.args, err)
+			continue
+		}
+		if reflect.ValueOf(c.Run).Pointer() != reflect.ValueOf(tt.handler).Pointer() {
+			t.Errorf("findCommand(%q) routed to %s", tt.args, c.Name)
+		}
+		if len(rest) != len(tt.rest) || (len(rest) > 0 && !reflect.DeepEqual(rest, tt.rest)) {
+			t.Errorf("findCommand(%q) left arguments %q, want %q", tt.args, rest, tt.rest)
+		}
+	}
+}
+
+func TestFindCommandUnknown(t *testing.T) {
+	_, _, err := findCommand([]string{"bogus", "-steps", "5"})
+	if err == nil || !strings.Contains(err.Error(), `unknown command "bogus"`) {
+		t.Errorf("findCommand(bogus) error = %v, want unknown command", err)
+	}
+}
+
+func TestFlagsOverrideConfigOnlyWhenGiven(t *testing.T) {
+	g := NewGame()
+	g.Pond.Gillespie = true
+	g.Pond.RateNoise = 0.2
+	g.BarDivisor = 12
+	path := filepath.Join(t.TempDir(), "modes.json")
+	if err := g.SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		args      []string
+		gillespie bool
+		noise     float64
+		divisor   int
+	}{
+		{[]string{"-config", path}, true, 0.2, 12},
+		{[]string{"-config", path, "-gillespie=false", "-bar-divisor", "3"}, false, 0.2, 3},
+	} {
+		fs := flag.NewFlagSet("run", flag.ContinueOnError)
+		var opts runOptions
+		opts.register(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		game, err := opts.newGame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if game.Pond.Gillespie != tt.gillespie || game.Pond.RateNoise != tt.noise || game.BarDivisor != tt.divisor {
+			t.Errorf("%q: gillespie %v, rate noise %g, bar divisor %d; want %v, %g, %d", tt.args,
+				game.Pond.Gillespie, game.Pond.RateNoise, game.BarDivisor, tt.gillespie, tt.noise, tt.divisor)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestStatusClock(t *testing.T) {
+	tests := []struct {
+		showTime bool
+		ticks    int
+		timeStep float64
+		want     string
+	}{
+		{false, 0, 1, "Sim Ticks: 0"},
+		{false, 1234, 0.5, "Sim Ticks: 1234"},
+		{true, 0, 1, "Sim Time: 0 s"},
+		{true, 1234, 1, "Sim Time: 1234 s"},
+		{true, 1234, 0.5, "Sim Time: 617 s"},
+		{true, 3, 0.001, "Sim Time: 0.003 s"},
+		{true, 123456, 1, "Sim Time: 1.235e+05 s"},
+	}
+	for _, tt := range tests {
+		if got := statusClock(tt.showTime, tt.ticks, tt.timeStep); got != tt.want {
+			t.Errorf("statusClock(%v, %d, %v) = %q, want %q", tt.showTime, tt.ticks, tt.timeStep, got, tt.want)
+		}
+	}
+}
+
+func TestStatusClockDefaultsToTicks(t *testing.T) {
+	g := NewGame()
+	if g.ShowSimTime || g.TimeStep != DefaultTimeStep {
+		t.Errorf("new game shows time %v with time step %v, want ticks and %v", g.ShowSimTime, g.TimeStep, DefaultTimeStep)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+)
+
+// --- Cloning ---
+
+// Clone returns a deep copy of the pond that can be run independently:
+// counts, reactions, statistics and held-back catalysts are copied, and the
+// clone gets its own random sources in the same state as the original's.
+// Left unchanged, the clone therefore follows the original step for step;
+// any change made to one of them (an injection, a rate) is the only source
+// of difference, which suits what-if experiments.
+//
+// Observers, the event log and grid neighbours belong to their owners
+// and are not carried over. Lineage attribution of the clone restarts its
+// random draws.
+func (p *Pond) Clone() *Pond {
+	q := *p
+	q.Molecules = maps.Clone(p.Molecules)
+	q.Notes = maps.Clone(p.Notes)
+	q.Reactions = make([]Reaction, len(p.Reactions))
+	for i, r := range p.Reactions {
+		q.Reactions[i] = cloneReaction(r)
+	}
+	q.FireCounts = slices.Clone(p.FireCounts)
+	q.stats.sums = maps.Clone(p.stats.sums)
+	q.stats.extinctions = maps.Clone(p.stats.extinctions)
+	q.ProductionCaps = maps.Clone(p.ProductionCaps)
+	q.produced = maps.Clone(p.produced)
+	q.MaxCount = maps.Clone(p.MaxCount)
+	q.Amounts = maps.Clone(p.Amounts)
+	q.pending = slices.Clone(p.pending)
+	q.mutantRoots = maps.Clone(p.mutantRoots)
+	if p.Controller != nil {
+		c := *p.Controller
+		q.Controller = &c
+	}
+	if p.Lineage != nil {
+		q.Lineage = make(map[string]map[int]int, len(p.Lineage))
+		for name, sources := range p.Lineage {
+			q.Lineage[name] = maps.Clone(sources)
+		}
+		q.lineageRand = rand.New(rand.NewSource(1))
+	}
+
+	q.rng = p.rng.Clone()
+	q.reactionRands = make([]*cloneableRand, len(p.reactionRands))
+	for i, rng := range p.reactionRands {
+		if rng != nil {
+			q.reactionRands[i] = rng.Clone()
+		}
+	}
+
+	q.observers, q.fireObservers, q.Events, q.neighbors = nil, nil, nil, nil
+	q.weights, q.propsCached, q.branchWeights, q.index = nil, false, nil, nil
+	return &q
+}
+
+// cloneReaction returns a copy of r that shares no slices with it.
+func cloneReaction(r Reaction) Reaction {
+	r.Reactants = slices.Clone(r.Reactants)
+	r.ByProducts = slices.Clone(r.ByProducts)
+	r.Catalysts = slices.Clone(r.Catalysts)
+	branches := make([]Branch, len(r.Branches))
+	for i, b := range r.Branches {
+		branches[i] = Branch{Products: slices.Clone(b.Products), Probability: b.Probability}
+	}
+	if r.Branches != nil {
+		r.Branches = branches
+	}
+	if r.MinReactantRatio != nil {
+		q := *r.MinReactantRatio
+		r.MinReactantRatio = &q
+	}
+	return r
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	p := NewPondWithSeed(5)
+	p.Run(1000)
+	q := p.Clone()
+	before := maps.Clone(p.Molecules)
+	fires := slices.Clone(p.FireCounts)
+
+	q.Run(1000)
+	if !maps.Equal(p.Molecules, before) || !slices.Equal(p.FireCounts, fires) {
+		t.Fatalf("stepping the clone changed the original: %v, want %v", p.Molecules, before)
+	}
+	if maps.Equal(q.Molecules, before) {
+		t.Fatal("the clone did not change when stepped")
+	}
+
+	cloned := maps.Clone(q.Molecules)
+	p.Run(1000)
+	if !maps.Equal(q.Molecules, cloned) {
+		t.Errorf("stepping the original changed the clone: %v, want %v", q.Molecules, cloned)
+	}
+	// Both started from the same random state, so they took the same path
+	if !maps.Equal(p.Molecules, q.Molecules) || !slices.Equal(p.FireCounts, q.FireCounts) {
+		t.Errorf("original %v and clone %v diverged without any change", p.Molecules, q.Molecules)
+	}
+}
+
+func TestCloneSharesNoReactions(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B", Catalysts: []string{"A"},
+			Branches: []Branch{{Products: []string{"B"}, Probability: 1}}})
+	q := p.Clone()
+	q.Reactions[0].Reactants[0] = "X"
+	q.Reactions[0].Catalysts[0] = "X"
+	q.Reactions[0].Branches[0].Products[0] = "X"
+	q.Reactions[0].Rate = 9
+	q.Molecules["A"] = 0
+
+	r := p.Reactions[0]
+	if r.Reactants[0] != "A" || r.Catalysts[0] != "A" || r.Branches[0].Products[0] != "B" || r.Rate != 0 {
+		t.Errorf("editing the clone's reaction changed the original: %+v", r)
+	}
+	if p.Molecules["A"] != 10 {
+		t.Errorf("original A = %d, want 10", p.Molecules["A"])
+	}
+}
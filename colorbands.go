@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image/color"
+)
+
+// --- Count Color Bands ---
+
+// ColorBand colors the bar of any species whose count is at least Min, up to
+// the next band's Min. For example bands at 0, 100, 1000 and 5000 give grey
+// below 100, white below 1000, orange below 5000 and green above.
+type ColorBand struct {
+	Min   int      `json:"min"`
+	Color HexColor `json:"color"`
+}
+
+// HexColor is an opaque color written as "#rrggbb" in configs.
+type HexColor color.RGBA
+
+// MarshalText implements encoding.TextMarshaler.
+func (c HexColor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing "#rrggbb".
+func (c *HexColor) UnmarshalText(text []byte) error {
+	if len(text) != 7 || text[0] != '#' {
+		return fmt.Errorf("invalid color %q (want #rrggbb)", text)
+	}
+	var rgb [3]byte
+	if _, err := hex.Decode(rgb[:], text[1:]); err != nil {
+		return fmt.Errorf("invalid color %q (want #rrggbb)", text)
+	}
+	*c = HexColor{rgb[0], rgb[1], rgb[2], 255}
+	return nil
+}
+
+// bandColor returns the color of the band count falls in: the one with the
+// highest Min not above count, in whatever order bands are listed. It
+// reports false when count is below every band.
+func bandColor(bands []ColorBand, count int) (color.RGBA, bool) {
+	best := -1
+	for i, b := range bands {
+		if b.Min <= count && (best < 0 || b.Min > bands[best].Min) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return color.RGBA{}, false
+	}
+	return color.RGBA(bands[best].Color), true
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+)
+
+func TestBandColor(t *testing.T) {
+	grey := HexColor{128, 128, 128, 255}
+	white := HexColor{255, 255, 255, 255}
+	orange := HexColor{255, 165, 0, 255}
+	green := HexColor{0, 255, 0, 255}
+	bands := []ColorBand{{1000, orange}, {100, white}, {5000, green}, {10, grey}} // Any order
+
+	tests := []struct {
+		count int
+		want  HexColor
+		ok    bool
+	}{
+		{0, HexColor{}, false}, // Below the lowest threshold
+		{9, HexColor{}, false},
+		{10, grey, true},
+		{99, grey, true},
+		{100, white, true},
+		{999, white, true},
+		{1000, orange, true},
+		{4999, orange, true},
+		{5000, green, true},
+		{1000000, green, true}, // Above the highest threshold
+	}
+	for _, tt := range tests {
+		got, ok := bandColor(bands, tt.count)
+		if ok != tt.ok || got != color.RGBA(tt.want) {
+			t.Errorf("bandColor(%d) = %v, %t; want %v, %t", tt.count, got, ok, color.RGBA(tt.want), tt.ok)
+		}
+	}
+	if _, ok := bandColor(nil, 50); ok {
+		t.Error("bandColor with no bands reported a color")
+	}
+}
+
+func TestColorBandJSON(t *testing.T) {
+	var bands []ColorBand
+	if err := json.Unmarshal([]byte(`[{"min": 100, "color": "#ffa500"}]`), &bands); err != nil {
+		t.Fatal(err)
+	}
+	if want := (HexColor{255, 165, 0, 255}); len(bands) != 1 || bands[0].Min != 100 || bands[0].Color != want {
+		t.Errorf("decoded %+v", bands)
+	}
+	data, err := json.Marshal(bands)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"min":100,"color":"#ffa500"}]`; string(data) != want {
+		t.Errorf("encoded %s, want %s", data, want)
+	}
+
+	for _, bad := range []string{`"ffa500"`, `"#ffa50"`, `"#gggggg"`, `"#ffa5000"`} {
+		var c HexColor
+		if err := json.Unmarshal([]byte(bad), &c); err == nil {
+			t.Errorf("decoding %s as a color succeeded", bad)
+		}
+	}
+}
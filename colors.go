@@ -0,0 +1,51 @@
+package main
+
+import (
+	"hash/fnv"
+	"image/color"
+	"math"
+)
+
+// --- Species Colors ---
+
+// colorForName hashes a species name to a stable, fully opaque color so that
+// species without a configured highlight still get a consistent color.
+// Only the hue varies; saturation and value stay bright enough to read on
+// the black background.
+func colorForName(name string) color.RGBA {
+	return hashedColor(name, 0.55, 0.95)
+}
+
+// hashedColor hashes a species name to a hue with the given saturation and value.
+func hashedColor(name string, saturation, value float64) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	hue := float64(h.Sum32()%360) / 360
+	return hsvToRGBA(hue, saturation, value, 255)
+}
+
+// hsvToRGBA converts a hue in [0,1) with saturation and value in [0,1] to RGBA.
+func hsvToRGBA(h, s, v float64, alpha uint8) color.RGBA {
+	i := math.Floor(h * 6)
+	f := h*6 - i
+	p := v * (1 - s)
+	q := v * (1 - f*s)
+	t := v * (1 - (1-f)*s)
+
+	var r, g, b float64
+	switch int(i) % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return color.RGBA{uint8(r * 255), uint8(g * 255), uint8(b * 255), alpha}
+}
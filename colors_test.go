@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestColorForNameIsStable(t *testing.T) {
+	for _, name := range []string{"A", "E", "Replicator", "X_2"} {
+		if first, second := colorForName(name), colorForName(name); first != second {
+			t.Errorf("colorForName(%q) = %v then %v", name, first, second)
+		}
+		if c := colorForName(name); c.A != 255 {
+			t.Errorf("colorForName(%q) alpha = %d, want 255", name, c.A)
+		}
+	}
+}
+
+func TestColorForNameIsDistinct(t *testing.T) {
+	seen := map[string]string{}
+	for _, name := range []string{"A", "B", "C", "D", "E", "F"} {
+		c := colorForName(name)
+		key := string([]byte{c.R, c.G, c.B})
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s share color %v", other, name, c)
+		}
+		seen[key] = name
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// --- Protocell Population ---
+
+// Defaults for the compartment population.
+const (
+	DefaultDivisionSize    = 4000 // Molecules at which a compartment divides
+	DefaultMaxCompartments = 64   // Population cap; excess compartments are washed out
+	SizeHistogramBins      = 10
+)
+
+// CompartmentPopulation is a population of protocells (vesicles), each a
+// Pond running the same reactions on its own contents. A compartment whose
+// total population reaches DivisionSize divides in two, each molecule going
+// to either daughter with equal probability. When divisions push the
+// population above MaxCompartments, randomly chosen compartments are
+// removed, so faster-growing contents come to dominate.
+type CompartmentPopulation struct {
+	Compartments    []*Pond
+	DivisionSize    int
+	MaxCompartments int
+	Feed            map[string]int // Food taken up by every compartment each tick
+
+	Tick            int   // Ticks run so far
+	DivisionsByTick []int // Divisions during each tick, oldest first
+
+	cfg      *Config // Pond settings every compartment shares
+	rng      *cloneableRand
+	nextSeed int64 // Seed for the next compartment created
+}
+
+// PopulationStats summarizes the population at one moment.
+type PopulationStats struct {
+	Count            int     // Number of compartments
+	MeanSize         float64 // Mean total molecule count per compartment
+	DivisionsPerTick float64 // Mean divisions per tick so far
+	LastDivisions    int     // Divisions during the latest tick
+}
+
+// NewCompartmentPopulation creates n compartments, each a copy of the
+// config's pond with its own seed derived from cfg.Seed.
+func NewCompartmentPopulation(cfg *Config, n int) *CompartmentPopulation {
+	cp := &CompartmentPopulation{
+		DivisionSize:    DefaultDivisionSize,
+		MaxCompartments: DefaultMaxCompartments,
+		cfg:             cfg,
+		rng:             newCloneableRand(cfg.Seed),
+		nextSeed:        cfg.Seed + 1,
+	}
+	for i := 0; i < n; i++ {
+		cp.Compartments = append(cp.Compartments, cp.newCompartment(cfg.Molecules, pooledReactions(cfg.Reactions)))
+	}
+	return cp
+}
+
+// newCompartment creates a compartment with the config's pond settings
+// (volume, rounding, caps) and the given contents and reactions.
+func (cp *CompartmentPopulation) newCompartment(counts map[string]int, reactions []Reaction) *Pond {
+	p := cp.cfg.NewPond()
+	p.Molecules = maps.Clone(counts)
+	p.Reactions = append([]Reaction(nil), reactions...)
+	p.Seed = cp.nextSeed
+	p.rng = newCloneableRand(p.Seed)
+	cp.nextSeed++
+	return p
+}
+
+// Run feeds every compartment and advances it by steps steps, then divides
+// those that reached DivisionSize and washes out any excess. It counts as
+// one tick.
+func (cp *CompartmentPopulation) Run(steps int) {
+	for _, c := range cp.Compartments {
+		for name, amount := range cp.Feed {
+			c.Molecules[name] += amount
+		}
+		c.Run(steps)
+		c.Decay()
+	}
+
+	divisions := 0
+	for i, n := 0, len(cp.Compartments); i < n; i++ { // Newborn daughters wait for the next tick
+		if cp.Compartments[i].TotalPopulation() >= cp.DivisionSize {
+			cp.Divide(i)
+			divisions++
+		}
+	}
+	for cp.MaxCompartments > 0 && len(cp.Compartments) > cp.MaxCompartments {
+		i := cp.rng.Intn(len(cp.Compartments))
+		cp.Compartments = append(cp.Compartments[:i], cp.Compartments[i+1:]...)
+	}
+
+	cp.Tick++
+	cp.DivisionsByTick = append(cp.DivisionsByTick, divisions)
+}
+
+// Divide splits compartment i in two: each molecule goes to either daughter
+// with equal probability. The first daughter replaces i, the second is
+// appended to the population.
+func (cp *CompartmentPopulation) Divide(i int) {
+	parent := cp.Compartments[i]
+	daughter := make(map[string]int, len(parent.Molecules))
+	for _, name := range parent.SpeciesNames() {
+		count := parent.Molecules[name]
+		moved := 0
+		for k := 0; k < count; k++ {
+			if cp.rng.Intn(2) == 0 {
+				moved++
+			}
+		}
+		parent.Molecules[name] = count - moved
+		daughter[name] = moved
+	}
+	cp.Compartments = append(cp.Compartments, cp.newCompartment(daughter, parent.Reactions))
+}
+
+// Sizes returns the total molecule count of every compartment.
+func (cp *CompartmentPopulation) Sizes() []int {
+	sizes := make([]int, len(cp.Compartments))
+	for i, c := range cp.Compartments {
+		sizes[i] = c.TotalPopulation()
+	}
+	return sizes
+}
+
+// PopulationStats returns the compartment count, mean size and division rate.
+func (cp *CompartmentPopulation) PopulationStats() PopulationStats {
+	stats := PopulationStats{Count: len(cp.Compartments)}
+	total := 0
+	for _, size := range cp.Sizes() {
+		total += size
+	}
+	if stats.Count > 0 {
+		stats.MeanSize = float64(total) / float64(stats.Count)
+	}
+	divisions := 0
+	for _, d := range cp.DivisionsByTick {
+		divisions += d
+	}
+	if n := len(cp.DivisionsByTick); n > 0 {
+		stats.DivisionsPerTick = float64(divisions) / float64(n)
+		stats.LastDivisions = cp.DivisionsByTick[n-1]
+	}
+	return stats
+}
+
+// SizeHistogram counts compartments in bins equal-width bins spanning
+// [0, DivisionSize); compartments at or above DivisionSize land in the last bin.
+func (cp *CompartmentPopulation) SizeHistogram(bins int) []int {
+	hist := make([]int, bins)
+	if bins == 0 || cp.DivisionSize <= 0 {
+		return hist
+	}
+	for _, size := range cp.Sizes() {
+		bin := size * bins / cp.DivisionSize
+		hist[min(max(bin, 0), bins-1)]++
+	}
+	return hist
+}
+
+// ParseFeed parses a feed such as "A=20,B=20" into per-species amounts.
+func ParseFeed(s string) (map[string]int, error) {
+	feed := make(map[string]int)
+	if strings.TrimSpace(s) == "" {
+		return feed, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		name, amount, ok := strings.Cut(strings.TrimSpace(item), "=")
+		n, err := strconv.Atoi(amount)
+		if !ok || err != nil || n < 0 || !isSpeciesName(name) {
+			return nil, fmt.Errorf("invalid feed item %q (want species=amount)", item)
+		}
+		feed[name] = n
+	}
+	return feed, nil
+}
+
+// FormatSizeHistogram renders a size histogram as one text bar per bin.
+func FormatSizeHistogram(hist []int, divisionSize int) string {
+	var b strings.Builder
+	for i, n := range hist {
+		lo := i * divisionSize / len(hist)
+		fmt.Fprintf(&b, "%6d+ | %s %d\n", lo, strings.Repeat("#", n), n)
+	}
+	return b.String()
+}
+
+// String formats the stats on one line.
+func (s PopulationStats) String() string {
+	return fmt.Sprintf("compartments %d | mean size %.1f | divisions/tick %.2f (last %d)",
+		s.Count, s.MeanSize, s.DivisionsPerTick, s.LastDivisions)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCompartmentDivisions(t *testing.T) {
+	cfg := DefaultConfig() // 1501 molecules
+	cp := NewCompartmentPopulation(cfg, 1)
+	cp.Divide(0) // 0 splits into 0 and 1
+	cp.Divide(1) // 1 splits into 1 and 2
+
+	stats := cp.PopulationStats()
+	if stats.Count != 3 {
+		t.Fatalf("%d compartments after two divisions, want 3", stats.Count)
+	}
+	if want := 1501.0 / 3; math.Abs(stats.MeanSize-want) > 1e-9 {
+		t.Errorf("mean size %v, want %v", stats.MeanSize, want)
+	}
+
+	// Each molecule picks a daughter by a fair coin: about half, then a quarter each
+	sizes := cp.Sizes()
+	for i, want := range []float64{1501.0 / 2, 1501.0 / 4, 1501.0 / 4} {
+		sd := math.Sqrt(want / 2) // Binomial spread, roughly
+		if math.Abs(float64(sizes[i])-want) > 5*sd {
+			t.Errorf("compartment %d has %d molecules, want about %.0f", i, sizes[i], want)
+		}
+	}
+
+	hist := cp.SizeHistogram(4) // Bins of 1000 molecules
+	if want := []int{3, 0, 0, 0}; !slices.Equal(hist, want) {
+		t.Errorf("size histogram %v, want %v", hist, want)
+	}
+	cp.DivisionSize = 1000 // Bins of 250 molecules
+	want := make([]int, 4)
+	for _, size := range sizes {
+		want[min(size/250, 3)]++
+	}
+	if hist := cp.SizeHistogram(4); !slices.Equal(hist, want) {
+		t.Errorf("size histogram %v for sizes %v, want %v", hist, sizes, want)
+	}
+}
+
+func TestCompartmentRunDivides(t *testing.T) {
+	cp := NewCompartmentPopulation(DefaultConfig(), 2)
+	cp.DivisionSize = 1400 // Below the starting 1501, so both divide on the first tick
+	cp.Run(10)
+	if stats := cp.PopulationStats(); stats.Count != 4 || stats.LastDivisions != 2 || stats.DivisionsPerTick != 2 {
+		t.Errorf("after one tick: %v, want 4 compartments from 2 divisions", stats)
+	}
+}
+
+func TestCompartmentsShareConfigSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Volume = 3
+	cfg.Rounding = RoundFloor
+	cp := NewCompartmentPopulation(cfg, 2)
+	cp.Divide(0)
+	for i, c := range cp.Compartments {
+		if c.Volume != 3 || c.Rounding != RoundFloor {
+			t.Errorf("compartment %d has volume %v and rounding %v, want 3 and floor", i, c.Volume, c.Rounding)
+		}
+	}
+	if seeds := []int64{cp.Compartments[0].Seed, cp.Compartments[1].Seed, cp.Compartments[2].Seed}; !slices.Equal(seeds, []int64{1, 2, 3}) {
+		t.Errorf("compartment seeds %v, want 1, 2, 3", seeds)
+	}
+}
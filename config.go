@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+)
+
+// --- Experiment Configuration ---
+
+// Config is a complete, reproducible experiment: the chemistry plus the
+// simulation parameters needed to replay it.
+type Config struct {
+	Seed               int64               `json:"seed"`
+	StepsPerTick       int                 `json:"stepsPerTick"`
+	EmergenceThreshold int                 `json:"emergenceThreshold"`
+	Volume             float64             `json:"volume,omitempty"`
+	Rounding           RoundingMode        `json:"rounding,omitempty"`
+	Molecules          map[string]int      `json:"molecules"`
+	Reactions          []Reaction          `json:"reactions"`
+	ColorBands         []ColorBand         `json:"colorBands,omitempty"`     // Count-dependent bar colors for every species
+	Notes              map[string]string   `json:"notes,omitempty"`          // What each species stands for
+	ProductionCaps     map[string]int      `json:"productionCaps,omitempty"` // Units of a species that may be made per tick
+	MaxCount           map[string]int      `json:"maxCount,omitempty"`       // Carrying capacity per species
+	Emergence          *EmergenceCondition `json:"emergence,omitempty"`      // Replaces the emergenceThreshold test on E
+	Gillespie          bool                `json:"gillespie,omitempty"`      // Select reactions by propensity in continuous time
+	RateNoise          float64             `json:"rateNoise,omitempty"`      // Standard deviation of per-step noise on rates
+	ODEThreshold       int                 `json:"odeThreshold,omitempty"`   // Integrate while every reactant has this many molecules
+	Hybrid             bool                `json:"hybrid,omitempty"`         // With odeThreshold, integrate only the abundant species
+	Batch              bool                `json:"batch,omitempty"`          // Apply runs of repeated selections at once
+	MutationRate       float64             `json:"mutationRate,omitempty"`   // Probability that an autocatalytic copy mutates
+	MutationSpread     float64             `json:"mutationSpread,omitempty"` // Standard deviation of a mutant's log rate change
+	Guarded            bool                `json:"guarded,omitempty"`        // Re-check reactants just before every fire
+	TimeStep           float64             `json:"timeStep,omitempty"`       // Simulated seconds per tick
+	BarDivisor         *int                `json:"barDivisor,omitempty"`     // Molecules per pixel of a linear bar; 0 scales to the highest count
+}
+
+// LoadConfig reads and validates an experiment config from a JSON file, or
+// from a YAML file with the same schema when the path ends in .yaml or .yml.
+// Missing parameters fall back to the built-in defaults.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	if isYAMLPath(path) {
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("parsing config %s: %w", path, err)
+		}
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if cfg.StepsPerTick == 0 {
+		cfg.StepsPerTick = DefaultStepsPerTick
+	}
+	if cfg.EmergenceThreshold == 0 {
+		cfg.EmergenceThreshold = DefaultEmergenceThreshold
+	}
+	if cfg.TimeStep == 0 {
+		cfg.TimeStep = DefaultTimeStep
+	}
+	if cfg.MutationSpread == 0 {
+		cfg.MutationSpread = DefaultMutationSpread
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// LoadCounts reads molecule counts from a JSON object such as
+// {"A": 120, "E": 3400}, e.g. the steady state of an earlier run.
+func LoadCounts(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading counts: %w", err)
+	}
+	var counts map[string]int
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, fmt.Errorf("parsing counts %s: %w", path, err)
+	}
+	for name, count := range counts {
+		if count < 0 {
+			return nil, fmt.Errorf("counts %s: %q has negative count %d", path, name, count)
+		}
+	}
+	return counts, nil
+}
+
+// Validate checks that the parameters are usable and that every reaction only
+// refers to known molecules. Besides the declared molecules, any species some
+// reaction produces is known: such emergent species (e.g. "A + B -> AB") need
+// not be declared and appear in the pond when first produced.
+func (c *Config) Validate() error {
+	if c.Volume < 0 {
+		return fmt.Errorf("volume must not be negative, got %g", c.Volume)
+	}
+	if c.StepsPerTick < 0 {
+		return fmt.Errorf("stepsPerTick must not be negative, got %d", c.StepsPerTick)
+	}
+	if c.RateNoise < 0 {
+		return fmt.Errorf("rateNoise must not be negative, got %g", c.RateNoise)
+	}
+	if c.ODEThreshold < 0 {
+		return fmt.Errorf("odeThreshold must not be negative, got %d", c.ODEThreshold)
+	}
+	if c.MutationRate < 0 || c.MutationRate > 1 {
+		return fmt.Errorf("mutationRate %g is outside [0,1]", c.MutationRate)
+	}
+	if c.MutationSpread < 0 {
+		return fmt.Errorf("mutationSpread must not be negative, got %g", c.MutationSpread)
+	}
+	if c.TimeStep < 0 {
+		return fmt.Errorf("timeStep must not be negative, got %g", c.TimeStep)
+	}
+	if c.BarDivisor != nil && *c.BarDivisor < 0 {
+		return fmt.Errorf("barDivisor must not be negative, got %d", *c.BarDivisor)
+	}
+	for name, count := range c.Molecules {
+		if count < 0 {
+			return fmt.Errorf("molecule %q has negative count %d", name, count)
+		}
+	}
+	known := make(map[string]bool, len(c.Molecules))
+	for name := range c.Molecules {
+		known[name] = true
+	}
+	for _, r := range c.Reactions {
+		for _, name := range r.Pooled().AllProducts() {
+			known[name] = true
+		}
+	}
+	for name, limit := range c.ProductionCaps {
+		if limit < 0 {
+			return fmt.Errorf("production cap of %q must not be negative, got %d", name, limit)
+		}
+		if !known[name] {
+			return fmt.Errorf("production cap refers to unknown molecule %q", name)
+		}
+	}
+	for name, limit := range c.MaxCount {
+		if limit < 0 {
+			return fmt.Errorf("max count of %q must not be negative, got %d", name, limit)
+		}
+		if !known[name] {
+			return fmt.Errorf("max count refers to unknown molecule %q", name)
+		}
+	}
+	if c.Emergence != nil {
+		for _, name := range c.Emergence.Species() {
+			if !known[name] {
+				return fmt.Errorf("emergence condition refers to unknown molecule %q", name)
+			}
+		}
+	}
+	for i, r := range c.Reactions {
+		r = r.Pooled()
+		if len(r.Reactants) == 0 {
+			return fmt.Errorf("reaction %d has no reactants", i+1)
+		}
+		if r.Product == "" && len(r.Branches) == 0 {
+			return fmt.Errorf("reaction %d has no product", i+1)
+		}
+		if r.HalfLife < 0 {
+			return fmt.Errorf("reaction %d has negative half-life %g", i+1, r.HalfLife)
+		}
+		if r.HalfLife > 0 && (len(r.Reactants) != 1 || len(r.AllCatalysts()) > 0) {
+			return fmt.Errorf("reaction %d has a half-life but is not a decay of a single uncatalyzed reactant", i+1)
+		}
+		for _, b := range r.Branches {
+			if len(b.Products) == 0 {
+				return fmt.Errorf("reaction %d has a branch without products", i+1)
+			}
+			if b.Probability < 0 {
+				return fmt.Errorf("reaction %d has a branch with negative probability %g", i+1, b.Probability)
+			}
+		}
+		if r.NeighborCatalyzed && r.CatalystDelay > 0 {
+			return fmt.Errorf("reaction %d cannot combine neighborCatalyzed with catalystDelay", i+1)
+		}
+		if r.Rate < 0 {
+			return fmt.Errorf("reaction %d has negative rate %g", i+1, r.Rate)
+		}
+		if r.ProductYield < 0 {
+			return fmt.Errorf("reaction %d has negative product yield %d", i+1, r.ProductYield)
+		}
+		if r.ProductYield > 1 && len(r.Branches) > 0 {
+			return fmt.Errorf("reaction %d cannot combine productYield with branches", i+1)
+		}
+		if r.SuccessProbability < 0 || r.SuccessProbability > 1 {
+			return fmt.Errorf("reaction %d has success probability %g outside [0,1]", i+1, r.SuccessProbability)
+		}
+		if q := r.MinReactantRatio; q != nil {
+			if !known[q.Numerator] || !known[q.Denominator] {
+				return fmt.Errorf("reaction %d has a reactant ratio over unknown molecules %q:%q", i+1, q.Numerator, q.Denominator)
+			}
+			if q.Ratio <= 0 {
+				return fmt.Errorf("reaction %d has non-positive reactant ratio %g", i+1, q.Ratio)
+			}
+		}
+		if r.ProductInhibition < 0 {
+			return fmt.Errorf("reaction %d has negative product inhibition %g", i+1, r.ProductInhibition)
+		}
+		if r.ProductInhibition > 0 && len(r.Branches) > 0 {
+			return fmt.Errorf("reaction %d cannot combine productInhibition with branches", i+1)
+		}
+		for _, name := range append(append([]string(nil), r.Reactants...), r.AllCatalysts()...) {
+			if !known[name] {
+				return fmt.Errorf("reaction %d refers to unknown molecule %q", i+1, name)
+			}
+		}
+	}
+	return nil
+}
+
+// NewPond builds the config's pond, seeding its random source so the run is reproducible.
+func (c *Config) NewPond() *Pond {
+	molecules := make(map[string]int, len(c.Molecules))
+	for name, count := range c.Molecules {
+		molecules[name] = count
+	}
+	return &Pond{
+		Seed:           c.Seed,
+		StepsPerTick:   c.StepsPerTick,
+		Volume:         c.Volume,
+		Rounding:       c.Rounding,
+		Molecules:      molecules,
+		Reactions:      pooledReactions(c.Reactions),
+		Notes:          maps.Clone(c.Notes),
+		ProductionCaps: maps.Clone(c.ProductionCaps),
+		MaxCount:       maps.Clone(c.MaxCount),
+		Gillespie:      c.Gillespie,
+		RateNoise:      c.RateNoise,
+		ODEThreshold:   c.ODEThreshold,
+		Hybrid:         c.Hybrid,
+		Batch:          c.Batch,
+		MutationRate:   c.MutationRate,
+		MutationSpread: c.MutationSpread,
+		Guarded:        c.Guarded,
+		Status:         message(MsgInitialized),
+		rng:            newCloneableRand(c.Seed),
+	}
+}
+
+// pooledReactions returns a copy of reactions with their pools resolved.
+func pooledReactions(reactions []Reaction) []Reaction {
+	pooled := make([]Reaction, len(reactions))
+	for i, r := range reactions {
+		pooled[i] = r.Pooled()
+	}
+	return pooled
+}
+
+// NewGame builds a Game around the config's pond.
+func (c *Config) NewGame() *Game {
+	g := newGameWithPond(c.NewPond())
+	g.StepsPerTick = c.StepsPerTick
+	g.EmergenceThreshold = c.EmergenceThreshold
+	g.Emergence = c.Emergence
+	g.ColorBands = append([]ColorBand(nil), c.ColorBands...)
+	if c.TimeStep > 0 {
+		g.TimeStep = c.TimeStep
+	}
+	if c.BarDivisor != nil {
+		g.BarDivisor = *c.BarDivisor
+	}
+	return g
+}
+
+// Config captures the game's current chemistry and parameters.
+func (g *Game) Config() *Config {
+	molecules := make(map[string]int, len(g.Pond.Molecules))
+	for name, count := range g.Pond.Molecules {
+		molecules[name] = count
+	}
+	// Catalysts still held back by a reaction count as present
+	for _, unit := range g.Pond.pending {
+		molecules[unit.Species]++
+	}
+	barDivisor := g.BarDivisor
+	return &Config{
+		Seed:               g.Pond.Seed,
+		StepsPerTick:       g.StepsPerTick,
+		EmergenceThreshold: g.EmergenceThreshold,
+		Volume:             g.Pond.Volume,
+		Rounding:           g.Pond.Rounding,
+		Molecules:          molecules,
+		Reactions:          append([]Reaction(nil), g.Pond.Reactions...),
+		ColorBands:         append([]ColorBand(nil), g.ColorBands...),
+		Notes:              maps.Clone(g.Pond.Notes),
+		ProductionCaps:     maps.Clone(g.Pond.ProductionCaps),
+		MaxCount:           maps.Clone(g.Pond.MaxCount),
+		Emergence:          g.Emergence,
+		Gillespie:          g.Pond.Gillespie,
+		RateNoise:          g.Pond.RateNoise,
+		ODEThreshold:       g.Pond.ODEThreshold,
+		Hybrid:             g.Pond.Hybrid,
+		Batch:              g.Pond.Batch,
+		MutationRate:       g.Pond.MutationRate,
+		MutationSpread:     g.Pond.MutationSpread,
+		Guarded:            g.Pond.Guarded,
+		TimeStep:           g.TimeStep,
+		BarDivisor:         &barDivisor,
+	}
+}
+
+// SaveConfig writes the game's current configuration as indented JSON, or as
+// YAML when the path ends in .yaml or .yml.
+func (g *Game) SaveConfig(path string) error {
+	data, err := json.MarshalIndent(g.Config(), "", "  ")
+	if err == nil && isYAMLPath(path) {
+		data, err = jsonToYAML(data)
+		data = bytes.TrimSuffix(data, []byte("\n"))
+	}
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(42))
+	g.StepsPerTick = 7
+	g.EmergenceThreshold = 123
+	g.Pond.Volume = 2.5
+	g.Pond.Reactions = append(g.Pond.Reactions, Reaction{
+		Reactants: []string{"A", "A"}, Product: "C", ByProducts: []string{"B"}, Rate: 2,
+	})
+	g.Pond.Molecules["A"] = 321
+	g.Pond.Gillespie = true
+	g.Pond.RateNoise = 0.1
+	g.Pond.ODEThreshold = 50
+	g.Pond.Hybrid = true
+	g.Pond.Batch = true
+	g.Pond.MutationRate = 0.01
+	g.Pond.MutationSpread = 0.3
+	g.Pond.Guarded = true
+	g.TimeStep = 0.5
+	g.BarDivisor = 0 // Scale to the highest count, which must not fall back to the default
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := g.SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := cfg.NewGame()
+
+	if got, want := loaded.Config(), g.Config(); !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded config = %+v\nwant %+v", got, want)
+	}
+	if loaded.Pond.Seed != 42 || loaded.StepsPerTick != 7 || loaded.EmergenceThreshold != 123 {
+		t.Errorf("parameters not restored: seed %d, steps/tick %d, threshold %d",
+			loaded.Pond.Seed, loaded.StepsPerTick, loaded.EmergenceThreshold)
+	}
+	p := loaded.Pond
+	if !p.Gillespie || p.RateNoise != 0.1 || p.ODEThreshold != 50 || !p.Hybrid || !p.Batch {
+		t.Errorf("modes not restored: gillespie %v, rate noise %g, ODE threshold %d, hybrid %v, batch %v",
+			p.Gillespie, p.RateNoise, p.ODEThreshold, p.Hybrid, p.Batch)
+	}
+	if p.MutationRate != 0.01 || p.MutationSpread != 0.3 || !p.Guarded {
+		t.Errorf("mutation and guard not restored: rate %g, spread %g, guarded %v", p.MutationRate, p.MutationSpread, p.Guarded)
+	}
+	if loaded.TimeStep != 0.5 || loaded.BarDivisor != 0 {
+		t.Errorf("display not restored: time step %g, bar divisor %d", loaded.TimeStep, loaded.BarDivisor)
+	}
+}
+
+func TestConfigDisplayDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plain.json")
+	data := `{"molecules": {"A": 10}, "reactions": ["A -> A"]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := cfg.NewGame()
+	if g.TimeStep != DefaultTimeStep || g.BarDivisor != DefaultBarDivisor || g.Pond.MutationSpread != DefaultMutationSpread {
+		t.Errorf("time step %g, bar divisor %d, mutation spread %g without settings; want the defaults %v, %d, %g",
+			g.TimeStep, g.BarDivisor, g.Pond.MutationSpread, DefaultTimeStep, DefaultBarDivisor, DefaultMutationSpread)
+	}
+}
+
+func TestSaveConfigKeepsLiveEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "experiment.json")
+	if err := newGameWithPond(NewPondWithSeed(1)).SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := cfg.NewGame()
+	g.Pond.Reactions[1].Disabled = true
+	g.Pond.Reactions[2].Rate = 3.5
+	if err := g.SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.Reactions[1].Disabled {
+		t.Error("reaction 2 is enabled again after saving and reloading")
+	}
+	if reloaded.Reactions[2].Rate != 3.5 {
+		t.Errorf("reaction 3 rate = %v after reloading, want 3.5", reloaded.Reactions[2].Rate)
+	}
+	if reloaded.Reactions[0].Disabled {
+		t.Error("reaction 1 was disabled by saving")
+	}
+}
+
+func TestConfigStepsPerTick(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fast.json")
+	data := `{"seed": 1, "stepsPerTick": 50, "molecules": {"A": 10, "B": 10}, "reactions": ["A + B -> A"]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := cfg.NewGame()
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Pond.StepCount != 50 {
+		t.Errorf("one update ran %d steps, want 50", g.Pond.StepCount)
+	}
+}
+
+func TestConfigStepsPerTickDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.json")
+	data := `{"molecules": {"A": 10}, "reactions": ["A -> A"]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.StepsPerTick != DefaultStepsPerTick {
+		t.Errorf("stepsPerTick = %d without a setting, want %d", cfg.StepsPerTick, DefaultStepsPerTick)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+// --- Rate Controller ---
+
+// Bounds on the rate a controller may set. The lower bound stays above zero
+// because a zero Rate means "default rate" to the selector.
+const (
+	MinControlledRate = 0.01
+	MaxControlledRate = 100
+)
+
+// RateController is a simple proportional controller that steers a species
+// towards a setpoint by adjusting the rate of the reaction that removes it.
+type RateController struct {
+	Species  string  // Species being regulated (e.g. "E")
+	Target   int     // Desired count of Species
+	Gain     float64 // Rate change per molecule of error per tick
+	Reaction int     // Index of the degradation reaction whose rate is adjusted
+}
+
+// Regulate applies one controller update. The error is target minus the
+// current count: a positive error (too few molecules) lowers the degradation
+// rate, a negative one raises it. Returns the new rate.
+func (c *RateController) Regulate(p *Pond) float64 {
+	if c.Reaction < 0 || c.Reaction >= len(p.Reactions) {
+		return 0
+	}
+	r := &p.Reactions[c.Reaction]
+
+	err := float64(c.Target - p.Molecules[c.Species])
+	rate := r.EffectiveRate() - c.Gain*err
+	if rate < MinControlledRate {
+		rate = MinControlledRate
+	}
+	if rate > MaxControlledRate {
+		rate = MaxControlledRate
+	}
+	r.Rate = rate
+	return rate
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestRateControllerDirection(t *testing.T) {
+	p := NewPondWithSeed(1)
+	c := &RateController{Species: "E", Target: 300, Gain: 0.001, Reaction: 3}
+
+	p.Molecules["E"] = 100 // Positive error: too few
+	if rate := c.Regulate(p); rate >= 1 {
+		t.Errorf("rate = %v with E below target, want below 1", rate)
+	}
+
+	p.Reactions[3].Rate = 1
+	p.Molecules["E"] = 500 // Negative error: too many
+	if rate := c.Regulate(p); rate <= 1 {
+		t.Errorf("rate = %v with E above target, want above 1", rate)
+	}
+
+	p.Molecules["E"] = 0
+	p.Reactions[3].Rate = MinControlledRate
+	if rate := c.Regulate(p); rate != MinControlledRate {
+		t.Errorf("rate = %v, want clamped to %v", rate, MinControlledRate)
+	}
+}
+
+func TestRateControllerTrendsToSetpoint(t *testing.T) {
+	uncontrolled := meanSetpointError(nil)
+	controlled := meanSetpointError(&RateController{Species: "E", Target: 300, Gain: 0.0005, Reaction: 3})
+	if controlled >= uncontrolled || controlled > 150 {
+		t.Errorf("mean distance from the setpoint is %d with the controller, %d without", controlled, uncontrolled)
+	}
+}
+
+// meanSetpointError runs a food-rich pond and returns how far E settles from
+// a setpoint of 300 on average.
+func meanSetpointError(c *RateController) int {
+	g := newGameWithPond(NewPondWithSeed(3))
+	g.Pond.Molecules["A"] = 100000
+	g.Pond.Molecules["B"] = 100000
+	g.Pond.Controller = c
+
+	for i := 0; i < 2000; i++ {
+		g.advance(100)
+	}
+	sum := 0
+	const samples = 500
+	for i := 0; i < samples; i++ {
+		g.advance(100)
+		sum += abs(g.Pond.Molecules["E"] - 300)
+	}
+	return sum / samples
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestMinTotalPopulation(t *testing.T) {
+	r := Reaction{Reactants: []string{"A"}, Product: "B", MinTotalPopulation: 100}
+	p := testPond(1, map[string]int{"A": 10, "B": 0, "F": 50}, r)
+	for i := 0; i < 5; i++ {
+		p.Step()
+	}
+	if p.Molecules["B"] != 0 {
+		t.Fatalf("fired at total population 60 below threshold 100: B = %d", p.Molecules["B"])
+	}
+
+	p.Molecules["F"] = 90 // Total is now exactly the threshold
+	p.Step()
+	if p.Molecules["B"] != 1 {
+		t.Errorf("did not fire at total population 100: B = %d", p.Molecules["B"])
+	}
+}
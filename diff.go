@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Snapshot Diff ---
+
+// CountChange is the change of one species between two snapshots.
+type CountChange struct {
+	Species       string
+	Before, After int
+}
+
+// Delta returns After minus Before.
+func (c CountChange) Delta() int {
+	return c.After - c.Before
+}
+
+// SnapshotDiff lists what changed between two snapshots.
+type SnapshotDiff struct {
+	TickBefore, TickAfter int
+	Counts                []CountChange // Only species whose count differs, sorted by name
+	RemovedReactions      []Reaction    // In the first snapshot but not the second
+	AddedReactions        []Reaction    // In the second snapshot but not the first
+}
+
+// DiffSnapshots compares two snapshots. A species missing from one side counts as 0.
+func DiffSnapshots(a, b *Snapshot) SnapshotDiff {
+	d := SnapshotDiff{TickBefore: a.Tick, TickAfter: b.Tick}
+
+	names := make(map[string]bool)
+	for name := range a.Config.Molecules {
+		names[name] = true
+	}
+	for name := range b.Config.Molecules {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		before, after := a.Config.Molecules[name], b.Config.Molecules[name]
+		if before != after {
+			d.Counts = append(d.Counts, CountChange{Species: name, Before: before, After: after})
+		}
+	}
+
+	d.RemovedReactions = missingReactions(a.Config.Reactions, b.Config.Reactions)
+	d.AddedReactions = missingReactions(b.Config.Reactions, a.Config.Reactions)
+	return d
+}
+
+// missingReactions returns the reactions of from that have no identical
+// counterpart in to, matching duplicates one for one.
+func missingReactions(from, to []Reaction) []Reaction {
+	available := make(map[string]int)
+	for _, r := range to {
+		available[reactionKey(r)]++
+	}
+	var missing []Reaction
+	for _, r := range from {
+		key := reactionKey(r)
+		if available[key] > 0 {
+			available[key]--
+			continue
+		}
+		missing = append(missing, r)
+	}
+	return missing
+}
+
+// reactionKey identifies a reaction by its saved form, so reactions that
+// print the same formula but differ in any field are told apart.
+func reactionKey(r Reaction) string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Sprintf("%#v", r)
+	}
+	return string(data)
+}
+
+// String renders the diff for the terminal.
+func (d SnapshotDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Tick: %d -> %d\n", d.TickBefore, d.TickAfter)
+	if len(d.Counts) == 0 {
+		b.WriteString("Counts: unchanged\n")
+	} else {
+		b.WriteString("Counts:\n")
+		for _, c := range d.Counts {
+			fmt.Fprintf(&b, "  %s: %d -> %d (%+d)\n", c.Species, c.Before, c.After, c.Delta())
+		}
+	}
+	if len(d.RemovedReactions) == 0 && len(d.AddedReactions) == 0 {
+		b.WriteString("Reactions: unchanged\n")
+	} else {
+		b.WriteString("Reactions:\n")
+	}
+	for _, r := range d.RemovedReactions {
+		fmt.Fprintf(&b, "  - %s\n", r)
+	}
+	for _, r := range d.AddedReactions {
+		fmt.Fprintf(&b, "  + %s\n", r)
+	}
+	return b.String()
+}
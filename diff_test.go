@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshotsCounts(t *testing.T) {
+	before := newGameWithPond(NewPondWithSeed(1)).Snapshot()
+	after := newGameWithPond(NewPondWithSeed(1))
+	after.TickCounter = 10
+	after.Pond.Molecules["A"] -= 40
+	after.Pond.Molecules["E"] += 25
+	after.Pond.Molecules["F"] = 3 // New species
+
+	d := DiffSnapshots(before, after.Snapshot())
+	want := []CountChange{
+		{Species: "A", Before: 500, After: 460},
+		{Species: "E", Before: 1, After: 26},
+		{Species: "F", Before: 0, After: 3},
+	}
+	if !reflect.DeepEqual(d.Counts, want) {
+		t.Errorf("count changes = %+v, want %+v", d.Counts, want)
+	}
+	for _, c := range d.Counts {
+		if (c.Species == "A") != (c.Delta() < 0) {
+			t.Errorf("%s delta %+d has the wrong sign", c.Species, c.Delta())
+		}
+	}
+	if len(d.RemovedReactions) != 0 || len(d.AddedReactions) != 0 {
+		t.Errorf("unchanged reactions reported: -%v +%v", d.RemovedReactions, d.AddedReactions)
+	}
+	if d.TickBefore != 0 || d.TickAfter != 10 {
+		t.Errorf("ticks = %d -> %d, want 0 -> 10", d.TickBefore, d.TickAfter)
+	}
+}
+
+func TestDiffSnapshotsReactions(t *testing.T) {
+	before := newGameWithPond(NewPondWithSeed(1)).Snapshot()
+	after := newGameWithPond(NewPondWithSeed(1))
+	after.Pond.Reactions[1].Disabled = true // Not part of the printed formula
+	after.Pond.Reactions = append(after.Pond.Reactions, Reaction{Reactants: []string{"C"}, Product: "B"})
+
+	d := DiffSnapshots(before, after.Snapshot())
+	if want := before.Config.Reactions[1:2]; !reflect.DeepEqual(d.RemovedReactions, want) {
+		t.Errorf("removed reactions = %v, want %v", d.RemovedReactions, want)
+	}
+	if want := after.Pond.Reactions[1:2]; len(d.AddedReactions) != 2 || !reflect.DeepEqual(d.AddedReactions[:1], want) {
+		t.Errorf("added reactions = %v, want the disabled reaction and C -> B", d.AddedReactions)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"image"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Autocatalytic Dominance ---
+
+// dominanceKey names the single series of the dominance history, stored in
+// parts per dominanceScale since History keeps integer counts.
+const (
+	dominanceKey   = "dominance"
+	dominanceScale = 1000
+)
+
+// AutocatalyticSpecies returns the species that catalyze a reaction on a
+// catalytic cycle and are produced on one, i.e. the members of the
+// self-sustaining catalytic core (E in the default chemistry), sorted.
+func (p *Pond) AutocatalyticSpecies() []string {
+	produced := map[string]bool{}
+	catalysts := map[string]bool{}
+	for _, cycle := range p.FindCatalyticCycles() {
+		for _, i := range cycle {
+			for _, name := range p.Reactions[i].AllProducts() {
+				produced[name] = true
+			}
+			for _, name := range p.Reactions[i].AllCatalysts() {
+				catalysts[name] = true
+			}
+		}
+	}
+	var species []string
+	for name := range catalysts {
+		if produced[name] {
+			species = append(species, name)
+		}
+	}
+	sort.Strings(species)
+	return species
+}
+
+// DominanceFraction returns the share of all molecules that belong to the
+// autocatalytic species, in [0,1]; 0 when the pond is empty.
+func (p *Pond) DominanceFraction() float64 {
+	total := p.TotalPopulation()
+	if total <= 0 {
+		return 0
+	}
+	set := 0
+	for _, name := range p.AutocatalyticSpecies() {
+		set += p.Molecules[name]
+	}
+	return float64(set) / float64(total)
+}
+
+// drawDominance plots the dominance fraction history over area on a fixed
+// 0-100% scale, on top of the count graph.
+func (g *Game) drawDominance(screen *ebiten.Image, area image.Rectangle) {
+	points := g.Dominance.Points()
+	text.Draw(screen, "Dominance 100%", basicfont.Face7x13, area.Max.X-102, area.Min.Y+14, g.Theme.Dominant)
+	if len(points) < 2 {
+		return
+	}
+	x0, y0 := float32(area.Min.X), float32(area.Max.Y)
+	xStep := float32(area.Dx()) / float32(len(points)-1)
+	yScale := float32(area.Dy()) / dominanceScale
+	draw := g.renderer()
+	for i := 1; i < len(points); i++ {
+		draw.strokeLine(screen,
+			x0+float32(i-1)*xStep, y0-float32(points[i-1].Last[dominanceKey])*yScale,
+			x0+float32(i)*xStep, y0-float32(points[i].Last[dominanceKey])*yScale,
+			g.Theme.Dominant)
+	}
+}
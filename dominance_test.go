@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAutocatalyticSpecies(t *testing.T) {
+	if got, want := NewPondWithSeed(1).AutocatalyticSpecies(), []string{"E"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AutocatalyticSpecies() = %v, want %v", got, want)
+	}
+}
+
+func TestDominanceFraction(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   float64
+	}{
+		{"all autocatalytic", map[string]int{"A": 0, "B": 0, "C": 0, "D": 0, "E": 400}, 1},
+		{"none autocatalytic", map[string]int{"A": 100, "B": 50, "C": 0, "D": 10, "E": 0}, 0},
+		{"quarter", map[string]int{"A": 200, "B": 100, "C": 0, "D": 0, "E": 100}, 0.25},
+		{"empty pond", map[string]int{"A": 0, "B": 0, "C": 0, "D": 0, "E": 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPondWithSeed(1)
+			p.Molecules = tt.counts
+			if got := p.DominanceFraction(); got != tt.want {
+				t.Errorf("DominanceFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDominanceFractionWithoutCycles(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	if got := p.DominanceFraction(); got != 0 {
+		t.Errorf("DominanceFraction() = %v without an autocatalytic set, want 0", got)
+	}
+}
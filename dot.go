@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Graphviz DOT Export ---
+
+// cycleHighlight is the DOT attribute list for nodes and edges on a catalytic cycle.
+const cycleHighlight = `color="red", penwidth=2`
+
+// DOT renders the reaction network as a Graphviz digraph. Species are
+// ellipses and reactions are boxes; reactant and product edges are solid,
+// catalysis edges dashed. Reactions on a catalytic cycle, and the product and
+// catalysis edges that close the cycle, carry the cycleHighlight attributes.
+func (p *Pond) DOT() string {
+	onCycle := make(map[int]bool)
+	cycleEdges := make(map[string]bool) // "R1->E", "E->R3" style keys
+	for _, cycle := range p.FindCatalyticCycles() {
+		for k, idx := range cycle {
+			onCycle[idx] = true
+			next := cycle[(k+1)%len(cycle)]
+			for _, product := range p.Reactions[idx].AllProducts() {
+				for _, c := range p.Reactions[next].AllCatalysts() {
+					if c == product {
+						cycleEdges[fmt.Sprintf("R%d->%s", idx+1, product)] = true
+						cycleEdges[fmt.Sprintf("%s->R%d", product, next+1)] = true
+					}
+				}
+			}
+		}
+	}
+	edgeAttrs := func(key string, extra ...string) string {
+		attrs := extra
+		if cycleEdges[key] {
+			attrs = append(attrs, cycleHighlight)
+		}
+		if len(attrs) == 0 {
+			return ""
+		}
+		return " [" + strings.Join(attrs, ", ") + "]"
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph pond {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, name := range p.SpeciesNames() {
+		fmt.Fprintf(&b, "  %q [shape=ellipse];\n", name)
+	}
+	for i, r := range p.Reactions {
+		node := fmt.Sprintf("R%d", i+1)
+		attrs := "shape=box"
+		if onCycle[i] {
+			attrs += ", " + cycleHighlight
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", node, attrs)
+
+		for _, reactant := range dedupe(r.Reactants) {
+			fmt.Fprintf(&b, "  %q -> %q;\n", reactant, node)
+		}
+		for _, product := range dedupe(r.AllProducts()) {
+			fmt.Fprintf(&b, "  %q -> %q%s;\n", node, product, edgeAttrs(node+"->"+product))
+		}
+		for _, c := range r.AllCatalysts() {
+			fmt.Fprintf(&b, "  %q -> %q%s;\n", c, node, edgeAttrs(c+"->"+node, "style=dashed"))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDOTHighlightsCycle(t *testing.T) {
+	dot := NewPondWithSeed(1).DOT() // R3 (D + A -> E, catalyzed by E) is the only cycle
+	marked := []string{
+		`"R3" [shape=box, color="red", penwidth=2];`,
+		`"R3" -> "E" [color="red", penwidth=2];`,
+		`"E" -> "R3" [style=dashed, color="red", penwidth=2];`,
+	}
+	unmarked := []string{
+		`"R1" [shape=box];`,
+		`"R2" [shape=box];`,
+		`"R4" [shape=box];`,
+		`"R1" -> "D";`,
+		`"R2" -> "E";`,
+		`"D" -> "R3";`,
+	}
+	for _, line := range append(marked, unmarked...) {
+		if !strings.Contains(dot, "  "+line+"\n") {
+			t.Errorf("DOT output lacks %s\n%s", line, dot)
+		}
+	}
+	if n := strings.Count(dot, cycleHighlight); n != len(marked) {
+		t.Errorf("%d highlighted elements, want %d\n%s", n, len(marked), dot)
+	}
+}
+
+func TestDOTWithoutCycles(t *testing.T) {
+	p := NewPondWithSeed(1)
+	p.Reactions = p.Reactions[:2]
+	if dot := p.DOT(); strings.Contains(dot, cycleHighlight) {
+		t.Errorf("acyclic network has highlighted elements:\n%s", dot)
+	}
+}
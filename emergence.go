@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- Emergence Conditions ---
+
+// anyAutocatalytic is the operand that stands for every autocatalytic species.
+const anyAutocatalytic = "any autocatalytic"
+
+// EmergenceCondition defines emergence as a combination of count
+// comparisons, e.g. "E > 1000 AND D > 100" or "any autocatalytic > 500".
+// AND binds tighter than OR; parentheses are not supported. The operand
+// "any autocatalytic" holds when any species of AutocatalyticSpecies does.
+// The zero EmergenceCondition never holds.
+type EmergenceCondition struct {
+	text  string
+	anyOf [][]countComparison // Alternatives (OR) of comparisons that must all hold (AND)
+}
+
+// countComparison compares a species' count with a constant.
+type countComparison struct {
+	Species string // Species name, or anyAutocatalytic
+	Op      string // One of >, >=, <, <=, ==, !=
+	Value   int
+}
+
+// comparisonOps are the operators, two-character ones first so ">=" is not read as ">".
+var comparisonOps = []string{">=", "<=", "==", "!=", ">", "<"}
+
+// ParseEmergenceCondition parses a condition such as "E > 1000 AND D > 100".
+func ParseEmergenceCondition(s string) (EmergenceCondition, error) {
+	c := EmergenceCondition{text: strings.TrimSpace(s)}
+	for _, alternative := range splitKeyword(c.text, "OR") {
+		var all []countComparison
+		for _, term := range splitKeyword(alternative, "AND") {
+			cmp, err := parseComparison(term)
+			if err != nil {
+				return EmergenceCondition{}, fmt.Errorf("emergence condition %q: %w", s, err)
+			}
+			all = append(all, cmp)
+		}
+		c.anyOf = append(c.anyOf, all)
+	}
+	return c, nil
+}
+
+// splitKeyword splits s at the whitespace-separated word keyword, in any case.
+func splitKeyword(s, keyword string) []string {
+	var parts []string
+	var part []string
+	for _, word := range strings.Fields(s) {
+		if strings.EqualFold(word, keyword) {
+			parts = append(parts, strings.Join(part, " "))
+			part = nil
+			continue
+		}
+		part = append(part, word)
+	}
+	return append(parts, strings.Join(part, " "))
+}
+
+// parseComparison parses "species op value", e.g. "E > 1000".
+func parseComparison(term string) (countComparison, error) {
+	for _, op := range comparisonOps {
+		lhs, rhs, ok := strings.Cut(term, op)
+		if !ok {
+			continue
+		}
+		name := strings.Join(strings.Fields(lhs), " ")
+		if !isSpeciesName(name) && !strings.EqualFold(name, anyAutocatalytic) {
+			return countComparison{}, fmt.Errorf("invalid species %q", name)
+		}
+		if strings.EqualFold(name, anyAutocatalytic) {
+			name = anyAutocatalytic
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(rhs))
+		if err != nil {
+			return countComparison{}, fmt.Errorf("invalid count %q", strings.TrimSpace(rhs))
+		}
+		return countComparison{Species: name, Op: op, Value: value}, nil
+	}
+	return countComparison{}, fmt.Errorf("comparison %q has no operator", term)
+}
+
+// Holds reports whether the condition is met by the pond's current counts.
+func (c EmergenceCondition) Holds(p *Pond) bool {
+	for _, all := range c.anyOf {
+		met := true
+		for _, cmp := range all {
+			if !cmp.holds(p) {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true
+		}
+	}
+	return false
+}
+
+// holds evaluates the comparison against the pond's counts.
+func (cmp countComparison) holds(p *Pond) bool {
+	if cmp.Species != anyAutocatalytic {
+		return compareCount(p.Molecules[cmp.Species], cmp.Op, cmp.Value)
+	}
+	for _, name := range p.AutocatalyticSpecies() {
+		if compareCount(p.Molecules[name], cmp.Op, cmp.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareCount applies op to count and value.
+func compareCount(count int, op string, value int) bool {
+	switch op {
+	case ">":
+		return count > value
+	case ">=":
+		return count >= value
+	case "<":
+		return count < value
+	case "<=":
+		return count <= value
+	case "==":
+		return count == value
+	case "!=":
+		return count != value
+	}
+	return false
+}
+
+// Species returns the species the condition names, excluding "any autocatalytic".
+func (c EmergenceCondition) Species() []string {
+	var names []string
+	for _, all := range c.anyOf {
+		for _, cmp := range all {
+			if cmp.Species != anyAutocatalytic {
+				names = append(names, cmp.Species)
+			}
+		}
+	}
+	return names
+}
+
+// String returns the condition as it was written.
+func (c EmergenceCondition) String() string {
+	return c.text
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c EmergenceCondition) MarshalText() ([]byte, error) {
+	return []byte(c.text), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" gives the zero condition.
+func (c *EmergenceCondition) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*c = EmergenceCondition{}
+		return nil
+	}
+	parsed, err := ParseEmergenceCondition(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCompoundAndEmergence(t *testing.T) {
+	cond, err := ParseEmergenceCondition("E > 1000 AND D > 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame()
+	g.Emergence = &cond
+	tests := []struct {
+		e, d int
+		want bool
+	}{
+		{0, 0, false},
+		{5000, 0, false}, // Only E holds
+		{0, 500, false},  // Only D holds
+		{1000, 500, false},
+		{1001, 101, true},
+	}
+	for _, tt := range tests {
+		g.Pond.Molecules["E"], g.Pond.Molecules["D"] = tt.e, tt.d
+		if got := g.Emerged(); got != tt.want {
+			t.Errorf("E=%d D=%d: Emerged() = %v, want %v", tt.e, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestEmergenceConditionHolds(t *testing.T) {
+	p := NewPondWithSeed(1)
+	p.Molecules = map[string]int{"A": 10, "B": 0, "C": 5, "D": 0, "E": 600}
+	tests := []struct {
+		cond string
+		want bool
+	}{
+		{"A >= 10", true},
+		{"A > 10", false},
+		{"B == 0 and C != 0", true},
+		{"A < 5 OR C <= 5", true},
+		{"A < 5 OR B > 0 AND C > 0", false}, // AND binds tighter
+		{"A > 5 AND B > 0 OR C > 0", true},
+		{"any autocatalytic > 500", true},
+		{"Any Autocatalytic > 600", false},
+	}
+	for _, tt := range tests {
+		cond, err := ParseEmergenceCondition(tt.cond)
+		if err != nil {
+			t.Errorf("ParseEmergenceCondition(%q): %v", tt.cond, err)
+			continue
+		}
+		if got := cond.Holds(p); got != tt.want {
+			t.Errorf("%q: Holds = %v, want %v", tt.cond, got, tt.want)
+		}
+	}
+	if (EmergenceCondition{}).Holds(p) {
+		t.Error("zero condition holds")
+	}
+}
+
+func TestParseEmergenceConditionErrors(t *testing.T) {
+	for _, s := range []string{"E", "E > many", "E > 5 AND", "> 5", "E F > 5"} {
+		if _, err := ParseEmergenceCondition(s); err == nil {
+			t.Errorf("ParseEmergenceCondition(%q) accepted", s)
+		}
+	}
+}
+
+func TestEmergenceConditionInConfig(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"emergence": "E > 1000 AND D > 100"}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Emergence == nil || cfg.Emergence.String() != "E > 1000 AND D > 100" {
+		t.Fatalf("Emergence = %v, want the compound condition", cfg.Emergence)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Config
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Emergence == nil || back.Emergence.String() != cfg.Emergence.String() {
+		t.Errorf("round trip gave %v", back.Emergence)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestReactionCreatesNewSpecies(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Molecules = map[string]int{"A": 5, "B": 3}
+	cfg.Reactions = []Reaction{{Reactants: []string{"A", "B"}, Product: "AB"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("config with an undeclared product rejected: %v", err)
+	}
+
+	g := cfg.NewGame()
+	if _, ok := g.Pond.Molecules["AB"]; ok {
+		t.Fatal("AB registered before it was produced")
+	}
+	g.advance(20)
+	if got := g.Pond.Molecules["AB"]; got != 3 {
+		t.Errorf("AB = %d after B ran out, want 3", got)
+	}
+	if !slices.Contains(g.Pond.SpeciesNames(), "AB") {
+		t.Errorf("SpeciesNames() = %v, want AB listed", g.Pond.SpeciesNames())
+	}
+
+	g.Draw(ebiten.NewImage(ScreenWidth, ScreenHeight)) // Must not panic on the new row
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// --- Fire Event Stream ---
+
+// EventFlushEvery is the number of ticks an event log buffers between flushes.
+const EventFlushEvery = 100
+
+// EventRecord is one successful fire, written as one JSON line.
+type EventRecord struct {
+	Tick      int      `json:"tick"`
+	Step      int      `json:"step"`
+	Reaction  int      `json:"reaction"` // 0-based index into the reactions
+	Reactants []string `json:"reactants"`
+	Products  []string `json:"products"` // The products actually made, so the fired branch's for a branching reaction
+}
+
+// EventLog writes every successful fire of a pond as JSON lines. Output is
+// buffered and flushed every EventFlushEvery ticks and on Close.
+type EventLog struct {
+	Count int // Events written so far
+
+	w      *bufio.Writer
+	enc    *json.Encoder
+	closer io.Closer
+	tick   int   // Tick the current fires belong to
+	err    error // First write error; later events are dropped
+}
+
+// NewEventLog writes events to w.
+func NewEventLog(w io.Writer) *EventLog {
+	bw := bufio.NewWriter(w)
+	return &EventLog{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// CreateEventLog creates (or truncates) the file at path and writes events to it.
+func CreateEventLog(path string) (*EventLog, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	l := NewEventLog(f)
+	l.closer = f
+	return l, nil
+}
+
+// record writes the fire of reaction idx (and branch, or -1) at the pond's current step.
+func (l *EventLog) record(p *Pond, idx, branch int) {
+	if l.err != nil {
+		return
+	}
+	r := p.Reactions[idx]
+	products := r.YieldedProducts()
+	if branch >= 0 {
+		products = r.Branches[branch].Products
+	}
+	l.err = l.enc.Encode(EventRecord{Tick: l.tick, Step: p.StepCount, Reaction: idx, Reactants: r.Reactants, Products: products})
+	if l.err == nil {
+		l.Count++
+	}
+}
+
+// Flush writes any buffered events and returns the first error seen.
+func (l *EventLog) Flush() error {
+	if err := l.w.Flush(); l.err == nil {
+		l.err = err
+	}
+	return l.err
+}
+
+// Close flushes the log and closes its file, if it opened one.
+func (l *EventLog) Close() error {
+	err := l.Flush()
+	if l.closer != nil {
+		if cerr := l.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// LogEvents sends every later fire of the game's pond to l, stamped with the
+// tick it happens in.
+func (g *Game) LogEvents(l *EventLog) {
+	l.tick = g.TickCounter + 1
+	g.Pond.Events = l
+	g.Pond.AddObserver(func(tick int, _ *Pond) {
+		l.tick = tick + 1
+		if tick%EventFlushEvery == 0 {
+			l.Flush()
+		}
+	})
+}
+
+// Close releases the game's output files, flushing the event log, and stops
+// its HTTP server.
+func (g *Game) Close() error {
+	err := g.shutdownServer()
+	if g.Pond.Events != nil {
+		if cerr := g.Pond.Events.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestEventLogJSONL(t *testing.T) {
+	g := NewGame()
+	var buf bytes.Buffer
+	l := NewEventLog(&buf)
+	g.LogEvents(l)
+	for i := 0; i < 5; i++ {
+		g.advance(g.StepsPerTick)
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, lastTick := 0, 1
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines++
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", lines, err, scanner.Bytes())
+		}
+		for _, key := range []string{"tick", "step", "reaction", "reactants", "products"} {
+			if _, ok := fields[key]; !ok {
+				t.Fatalf("line %d lacks %q: %s", lines, key, scanner.Bytes())
+			}
+		}
+
+		var event EventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Tick < lastTick || event.Tick > g.TickCounter {
+			t.Errorf("line %d at tick %d after tick %d, run ended at %d", lines, event.Tick, lastTick, g.TickCounter)
+		}
+		lastTick = event.Tick
+		if event.Reaction < 0 || event.Reaction >= len(g.Pond.Reactions) {
+			t.Fatalf("line %d names reaction %d", lines, event.Reaction)
+		}
+		if want := g.Pond.Reactions[event.Reaction].Reactants; !slices.Equal(event.Reactants, want) {
+			t.Errorf("line %d reactants %v, want %v", lines, event.Reactants, want)
+		}
+	}
+	if fires := g.Pond.TotalFires(); lines != fires || l.Count != fires || fires == 0 {
+		t.Errorf("%d lines, Count %d, want one per fire (%d)", lines, l.Count, fires)
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// --- Reaction Formula Parsing ---
+
+// ParseReaction parses a reaction written as a formula, for example
+//
+//	2A + B -> C + D [cat: E, rate: 2.0]
+//
+// Coefficients expand into repeated species. The first product becomes
+// Product and the rest become ByProducts. The optional bracketed annotations
+// accept "cat" (repeatable), "rate", "halflife", "success", "inhibition" and
+// the pools "from" and "to".
+func ParseReaction(s string) (Reaction, error) {
+	var r Reaction
+
+	body := strings.TrimSpace(s)
+	if open := strings.Index(body, "["); open >= 0 {
+		if !strings.HasSuffix(body, "]") {
+			return r, fmt.Errorf("reaction %q: unterminated annotation block", s)
+		}
+		if err := parseAnnotations(body[open+1:len(body)-1], &r); err != nil {
+			return r, fmt.Errorf("reaction %q: %w", s, err)
+		}
+		body = strings.TrimSpace(body[:open])
+	}
+
+	lhs, rhs, ok := strings.Cut(body, "->")
+	if !ok {
+		return r, fmt.Errorf("reaction %q: missing \"->\"", s)
+	}
+
+	reactants, err := parseSide(lhs)
+	if err != nil {
+		return r, fmt.Errorf("reaction %q: reactants: %w", s, err)
+	}
+	products, err := parseSide(rhs)
+	if err != nil {
+		return r, fmt.Errorf("reaction %q: products: %w", s, err)
+	}
+
+	r.Reactants = reactants
+	r.Product = products[0]
+	if len(products) > 1 {
+		r.ByProducts = products[1:]
+	}
+	return r, nil
+}
+
+// parseSide parses "2A + B" into the expanded species list [A A B].
+func parseSide(side string) ([]string, error) {
+	if strings.TrimSpace(side) == "" {
+		return nil, fmt.Errorf("no species")
+	}
+
+	var species []string
+	for _, term := range strings.Split(side, "+") {
+		term = strings.TrimSpace(term)
+		digits := strings.IndexFunc(term, func(c rune) bool { return !unicode.IsDigit(c) })
+		if digits < 0 {
+			return nil, fmt.Errorf("term %q has no species name", term)
+		}
+
+		coefficient := 1
+		if digits > 0 {
+			n, err := strconv.Atoi(term[:digits])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("term %q has invalid coefficient", term)
+			}
+			coefficient = n
+		}
+
+		name := strings.TrimSpace(term[digits:])
+		if !isSpeciesName(name) {
+			return nil, fmt.Errorf("unknown token %q", term)
+		}
+		for i := 0; i < coefficient; i++ {
+			species = append(species, name)
+		}
+	}
+	return species, nil
+}
+
+// isSpeciesName reports whether s is a species name, optionally in a pool
+// ("E@membrane"), each part a letter followed by letters, digits or underscores.
+func isSpeciesName(s string) bool {
+	if species, pool, ok := strings.Cut(s, "@"); ok {
+		return isBareName(species) && isBareName(pool)
+	}
+	return isBareName(s)
+}
+
+// isBareName reports whether s is a letter followed by letters, digits or underscores.
+func isBareName(s string) bool {
+	for i, c := range s {
+		if c == '_' || unicode.IsLetter(c) || (i > 0 && unicode.IsDigit(c)) {
+			continue
+		}
+		return false
+	}
+	return s != ""
+}
+
+// parseAnnotations applies "key: value" pairs such as "cat: E, rate: 2.0" to r.
+func parseAnnotations(block string, r *Reaction) error {
+	for _, item := range strings.Split(block, ",") {
+		key, value, ok := strings.Cut(item, ":")
+		if !ok {
+			return fmt.Errorf("annotation %q is not key: value", strings.TrimSpace(item))
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "cat", "catalyst":
+			if !isSpeciesName(value) {
+				return fmt.Errorf("invalid catalyst %q", value)
+			}
+			r.Catalysts = append(r.Catalysts, value)
+		case "rate":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 {
+				return fmt.Errorf("invalid rate %q", value)
+			}
+			r.Rate = rate
+		case "halflife", "half-life":
+			h, err := strconv.ParseFloat(value, 64)
+			if err != nil || h <= 0 {
+				return fmt.Errorf("invalid half-life %q", value)
+			}
+			r.HalfLife = h
+		case "success":
+			prob, err := strconv.ParseFloat(value, 64)
+			if err != nil || prob <= 0 || prob > 1 {
+				return fmt.Errorf("invalid success probability %q", value)
+			}
+			r.SuccessProbability = prob
+		case "from", "to":
+			if !isBareName(value) {
+				return fmt.Errorf("invalid pool %q", value)
+			}
+			if key == "from" {
+				r.FromPool = value
+			} else {
+				r.ToPool = value
+			}
+		case "inhibition":
+			k, err := strconv.ParseFloat(value, 64)
+			if err != nil || k < 0 {
+				return fmt.Errorf("invalid inhibition %q", value)
+			}
+			r.ProductInhibition = k
+		default:
+			return fmt.Errorf("unknown annotation %q", key)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts either the object form of a reaction or a formula
+// string understood by ParseReaction, so configs can list "A + B -> D".
+func (r *Reaction) UnmarshalJSON(data []byte) error {
+	var formula string
+	if err := json.Unmarshal(data, &formula); err == nil {
+		parsed, err := ParseReaction(formula)
+		if err != nil {
+			return err
+		}
+		*r = parsed
+		return nil
+	}
+
+	// The alias type drops this method so the default decoding applies.
+	type plain Reaction
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// String formats the reaction as a formula in the syntax ParseReaction accepts,
+// e.g. "2A + B -> C + D [cat: E, rate: 2]". Branching reactions list their
+// branches with percentages, which is for display only.
+func (r Reaction) String() string {
+	var b strings.Builder
+	b.WriteString(formatSide(r.Reactants))
+	b.WriteString(" -> ")
+	if len(r.Branches) > 0 {
+		total := 0.0
+		for _, br := range r.Branches {
+			total += br.Probability
+		}
+		for i, br := range r.Branches {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			b.WriteString(formatSide(br.Products))
+			if total > 0 {
+				fmt.Fprintf(&b, " (%.0f%%)", 100*br.Probability/total)
+			}
+		}
+	} else {
+		b.WriteString(formatSide(r.YieldedProducts()))
+	}
+
+	var annotations []string
+	for _, c := range r.AllCatalysts() {
+		annotations = append(annotations, "cat: "+c)
+	}
+	if r.Rate > 0 {
+		annotations = append(annotations, "rate: "+strconv.FormatFloat(r.Rate, 'g', -1, 64))
+	}
+	if r.HalfLife > 0 {
+		annotations = append(annotations, "halflife: "+strconv.FormatFloat(r.HalfLife, 'g', -1, 64))
+	}
+	if r.SuccessProbability > 0 {
+		annotations = append(annotations, "success: "+strconv.FormatFloat(r.SuccessProbability, 'g', -1, 64))
+	}
+	if r.FromPool != "" {
+		annotations = append(annotations, "from: "+r.FromPool)
+	}
+	if r.ToPool != "" {
+		annotations = append(annotations, "to: "+r.ToPool)
+	}
+	if r.ProductInhibition > 0 {
+		annotations = append(annotations, "inhibition: "+strconv.FormatFloat(r.ProductInhibition, 'g', -1, 64))
+	}
+	if len(annotations) > 0 {
+		b.WriteString(" [" + strings.Join(annotations, ", ") + "]")
+	}
+	return b.String()
+}
+
+// formatSide renders [A A B] as "2A + B", keeping first-appearance order.
+func formatSide(species []string) string {
+	var order []string
+	counts := make(map[string]int)
+	for _, s := range species {
+		if counts[s] == 0 {
+			order = append(order, s)
+		}
+		counts[s]++
+	}
+
+	terms := make([]string, len(order))
+	for i, s := range order {
+		if counts[s] > 1 {
+			terms[i] = strconv.Itoa(counts[s]) + s
+		} else {
+			terms[i] = s
+		}
+	}
+	return strings.Join(terms, " + ")
+}
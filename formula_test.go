@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseReaction(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    Reaction
+	}{
+		{"A + B -> D", Reaction{Reactants: []string{"A", "B"}, Product: "D"}},
+		{"A->B", Reaction{Reactants: []string{"A"}, Product: "B"}},
+		{"2A + B -> C + D [cat: E, rate: 2.0]", Reaction{
+			Reactants: []string{"A", "A", "B"}, Product: "C", ByProducts: []string{"D"},
+			Catalysts: []string{"E"}, Rate: 2,
+		}},
+		{"A -> B [cat: E, cat: F]", Reaction{Reactants: []string{"A"}, Product: "B", Catalysts: []string{"E", "F"}}},
+		{"A -> B [rate: 0.5]", Reaction{Reactants: []string{"A"}, Product: "B", Rate: 0.5}},
+		{"D1 + C_2 -> 3E", Reaction{Reactants: []string{"D1", "C_2"}, Product: "E", ByProducts: []string{"E", "E"}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseReaction(tt.formula)
+		if err != nil {
+			t.Errorf("ParseReaction(%q): %v", tt.formula, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseReaction(%q) = %+v, want %+v", tt.formula, got, tt.want)
+		}
+	}
+}
+
+func TestParseReactionErrors(t *testing.T) {
+	for _, formula := range []string{
+		"A + B",               // Missing arrow
+		"A + B => D",          // Wrong arrow
+		"-> B",                // No reactants
+		"A ->",                // No products
+		"A + $ -> B",          // Unknown token
+		"A + 2 -> B",          // Coefficient without a species
+		"0A -> B",             // Zero coefficient
+		"A -> B [foo: 1]",     // Unknown annotation
+		"A -> B [rate: fast]", // Invalid rate
+		"A -> B [cat: 1E]",    // Invalid catalyst
+		"A -> B [rate: 1",     // Unterminated annotations
+		"A -> B [cat E]",      // Annotation without a colon
+	} {
+		if r, err := ParseReaction(formula); err == nil {
+			t.Errorf("ParseReaction(%q) = %+v, want an error", formula, r)
+		}
+	}
+}
+
+func TestReactionUnmarshalFormula(t *testing.T) {
+	var reactions []Reaction
+	data := `["A + B -> D", {"reactants": ["D"], "product": "E", "catalysts": ["E"]}]`
+	if err := json.Unmarshal([]byte(data), &reactions); err != nil {
+		t.Fatal(err)
+	}
+	want := []Reaction{
+		{Reactants: []string{"A", "B"}, Product: "D"},
+		{Reactants: []string{"D"}, Product: "E", Catalysts: []string{"E"}},
+	}
+	if !reflect.DeepEqual(reactions, want) {
+		t.Errorf("unmarshalled %+v, want %+v", reactions, want)
+	}
+}
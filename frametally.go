@@ -0,0 +1,46 @@
+package main
+
+import "fmt"
+
+// --- Reaction of the Frame ---
+
+// frameTally counts the fires of each reaction during one frame, so the HUD
+// can show what drove the frame rather than only its last fire.
+type frameTally struct {
+	counts map[int]int // Reaction index -> fires this frame
+}
+
+// Add counts one fire of reaction idx.
+func (t *frameTally) Add(idx int) {
+	if t.counts == nil {
+		t.counts = map[int]int{}
+	}
+	t.counts[idx]++
+}
+
+// Reset starts a new frame.
+func (t *frameTally) Reset() {
+	clear(t.counts)
+}
+
+// Top returns the reaction fired most often this frame and its count, the
+// lowest index on a tie. It reports false when nothing fired.
+func (t *frameTally) Top() (int, int, bool) {
+	best, most := -1, 0
+	for idx, n := range t.counts {
+		if n > most || (n == most && idx < best) {
+			best, most = idx, n
+		}
+	}
+	return best, most, best >= 0
+}
+
+// frameSummary describes the reaction of the frame, e.g.
+// "R3 x812: D + A -> E (Cat: E)", or "" when nothing fired.
+func (g *Game) frameSummary() string {
+	idx, n, ok := g.frameFires.Top()
+	if !ok || idx >= len(g.Pond.Reactions) {
+		return ""
+	}
+	return fmt.Sprintf("R%d x%d: %s", idx+1, n, g.Pond.describeFire(fireEvent{Reaction: idx, Branch: -1, valid: true}))
+}
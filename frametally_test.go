@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrameTallyTop(t *testing.T) {
+	var tally frameTally
+	if _, _, ok := tally.Top(); ok {
+		t.Error("empty tally reports a top reaction")
+	}
+	for _, idx := range []int{2, 0, 2, 1, 2, 0} {
+		tally.Add(idx)
+	}
+	if idx, n, ok := tally.Top(); !ok || idx != 2 || n != 3 {
+		t.Errorf("Top() = %d, %d, %v, want 2, 3, true", idx, n, ok)
+	}
+	tally.Add(0)
+	if idx, n, _ := tally.Top(); idx != 0 || n != 3 {
+		t.Errorf("Top() on a tie = %d, %d, want the lower index 0, 3", idx, n)
+	}
+	tally.Reset()
+	if _, _, ok := tally.Top(); ok {
+		t.Error("tally reports a top reaction after Reset")
+	}
+}
+
+func TestFrameTallyFollowsUpdate(t *testing.T) {
+	g := newGameWithPond(testPond(1, map[string]int{"A": 1e6, "B": 1e6},
+		Reaction{Reactants: []string{"A"}, Product: "B", Rate: 1},
+		Reaction{Reactants: []string{"B"}, Product: "A", Rate: 4},
+	))
+	for frame := 0; frame < 3; frame++ {
+		before := make([]int, len(g.Pond.Reactions))
+		copy(before, g.Pond.FireCounts)
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+		fires := make([]int, len(g.Pond.Reactions))
+		for i := range fires {
+			fires[i] = g.Pond.FireCounts[i] - before[i]
+		}
+		idx, n, ok := g.frameFires.Top()
+		if !ok || idx != 1 || n != fires[1] {
+			t.Fatalf("frame %d: Top() = %d, %d, %v, want 1, %d (fires %v)", frame, idx, n, ok, fires[1], fires)
+		}
+		if want := "R2 x"; !strings.HasPrefix(g.frameSummary(), want) {
+			t.Errorf("frameSummary() = %q, want prefix %q", g.frameSummary(), want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// --- Random Network Generation ---
+
+// Shape of generated reactions.
+const (
+	MaxGeneratedReactants = 2   // Reactants per generated reaction: 1 or 2
+	CatalysisProbability  = 0.3 // Chance that a generated reaction gets a catalyst
+)
+
+// GenerateRandomReactions builds n random reactions over the given molecules,
+// respecting their roles: food molecules are never products and waste
+// molecules are never reactants. Any molecule may act as a catalyst.
+func GenerateRandomReactions(molecules []Molecule, n int, rng *rand.Rand) ([]Reaction, error) {
+	var consumable, producible []string
+	for _, m := range molecules {
+		if m.Role != RoleWaste {
+			consumable = append(consumable, m.Name)
+		}
+		if m.Role != RoleFood {
+			producible = append(producible, m.Name)
+		}
+	}
+	if len(consumable) == 0 {
+		return nil, fmt.Errorf("generate: no molecule may be a reactant")
+	}
+	if len(producible) == 0 {
+		return nil, fmt.Errorf("generate: no molecule may be a product")
+	}
+
+	reactions := make([]Reaction, n)
+	for i := range reactions {
+		r := Reaction{Product: producible[rng.Intn(len(producible))]}
+		for k := 1 + rng.Intn(MaxGeneratedReactants); k > 0; k-- {
+			r.Reactants = append(r.Reactants, consumable[rng.Intn(len(consumable))])
+		}
+		if rng.Float64() < CatalysisProbability {
+			r.Catalysts = []string{molecules[rng.Intn(len(molecules))].Name}
+		}
+		reactions[i] = r
+	}
+	return reactions, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateRandomReactionsRespectsRoles(t *testing.T) {
+	molecules := []Molecule{
+		{Name: "F1", Role: RoleFood},
+		{Name: "F2", Role: RoleFood},
+		{Name: "X"},
+		{Name: "Y"},
+		{Name: "W", Role: RoleWaste},
+	}
+	reactions, err := GenerateRandomReactions(molecules, 1000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range reactions {
+		for _, product := range r.AllProducts() {
+			if product == "F1" || product == "F2" {
+				t.Errorf("reaction %d produces food species %s: %v", i, product, r)
+			}
+		}
+		for _, reactant := range r.Reactants {
+			if reactant == "W" {
+				t.Errorf("reaction %d consumes waste species W: %v", i, r)
+			}
+		}
+	}
+}
+
+func TestGenerateRandomReactionsNoProducts(t *testing.T) {
+	molecules := []Molecule{{Name: "F", Role: RoleFood}}
+	if _, err := GenerateRandomReactions(molecules, 5, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("generated reactions with only food species, want an error")
+	}
+}
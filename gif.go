@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// --- Animated GIF Export ---
+
+// GIFFrameDelay is the delay between exported frames, in hundredths of a second.
+const GIFFrameDelay = 10
+
+// gifPalette returns the palette frames are drawn with: the theme's fixed
+// colors, the faded bar colors, the color bands and the colors of the pond's
+// species. Colors beyond the 256 a GIF allows are mapped to their nearest
+// entry.
+func (g *Game) gifPalette() color.Palette {
+	t := g.Theme
+	palette := color.Palette{color.Black, color.White, t.Dim, t.Header, t.Dominant,
+		opaque(t.PrecursorBar), opaque(t.ProductBar)}
+	for _, b := range g.ColorBands {
+		palette = append(palette, color.RGBA(b.Color))
+	}
+	for _, name := range g.Pond.SpeciesNames() {
+		c := t.SpeciesColor(name)
+		palette = append(palette, c, opaque(color.RGBA{c.R, c.G, c.B, 100}))
+	}
+	return palette[:min(len(palette), 256)]
+}
+
+// opaque blends c over the black background, as the bars appear on screen.
+func opaque(c color.RGBA) color.RGBA {
+	return color.RGBA{
+		uint8(int(c.R) * int(c.A) / 255),
+		uint8(int(c.G) * int(c.A) / 255),
+		uint8(int(c.B) * int(c.A) / 255),
+		255,
+	}
+}
+
+// renderBarsFrame draws the molecule table as Draw lays it out, bars
+// included, onto a paletted image sized to fit it.
+func (g *Game) renderBarsFrame(palette color.Palette) *image.Paletted {
+	names := g.Pond.SpeciesNames()
+	height := tableHeaderY + (len(names)+1)*tableRowStep + 20
+	img := image.NewPaletted(image.Rect(0, 0, ScreenWidth, height), palette)
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	label := func(s string, x, y int, c color.Color) {
+		d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: basicfont.Face7x13, Dot: fixed.P(x, y)}
+		d.DrawString(s)
+	}
+	label(fmt.Sprintf("Sim Ticks: %d", g.TickCounter), 20, 30, color.White)
+	label("Molecule", tableNameX, tableHeaderY, g.Theme.Header)
+	label("Count", tableCountX, tableHeaderY, g.Theme.Header)
+
+	yOffset := tableHeaderY + tableRowStep
+	rectMax := ScreenWidth - tableCountX - 150
+	for _, name := range names {
+		count := g.Pond.Molecules[name]
+		yOffset += tableRowStep
+
+		molColor := g.Theme.SpeciesColor(name)
+		barColor := color.RGBA{molColor.R, molColor.G, molColor.B, 100}
+		cue := ""
+		switch name {
+		case "D":
+			barColor = g.Theme.PrecursorBar
+		case "E":
+			barColor = g.Theme.ProductBar
+			if count > g.EmergenceThreshold {
+				molColor = g.Theme.Dominant
+				cue = g.Theme.DominantCue
+			}
+		}
+		if band, ok := bandColor(g.ColorBands, count); ok {
+			barColor = band
+		}
+
+		bar := image.Rect(0, 0, barWidth(count, rectMax, g.barDivisor(rectMax), g.LogBars), 15).Add(image.Pt(tableCountX+80, yOffset-11))
+		draw.Draw(img, bar, image.NewUniform(opaque(barColor)), image.Point{}, draw.Src)
+		label(name, tableNameX, yOffset, molColor)
+		label(strconv.Itoa(count), tableCountX, yOffset, molColor)
+		if cue != "" {
+			label(cue, tableCountX+80+rectMax+5, yOffset, molColor)
+		}
+	}
+	return img
+}
+
+// ExportGIF runs the game to tick from, then records the bar chart every
+// every ticks up to and including tick to, and writes the animation to w.
+func ExportGIF(w io.Writer, g *Game, from, to, every int) error {
+	if from < g.TickCounter || to < from || every < 1 {
+		return fmt.Errorf("invalid tick range %d-%d every %d (game is at tick %d)", from, to, every, g.TickCounter)
+	}
+	g.RunTo(from)
+
+	anim := &gif.GIF{}
+	for {
+		// Species may appear mid-run, so each frame gets a palette of its own
+		anim.Image = append(anim.Image, g.renderBarsFrame(g.gifPalette()))
+		anim.Delay = append(anim.Delay, GIFFrameDelay)
+		if g.TickCounter+every > to {
+			break
+		}
+		for i := 0; i < every; i++ {
+			g.advance(g.StepsPerTick)
+		}
+	}
+	anim.Config = image.Config{ColorModel: anim.Image[0].Palette, Width: ScreenWidth, Height: anim.Image[0].Bounds().Dy()}
+	for _, frame := range anim.Image {
+		anim.Config.Height = max(anim.Config.Height, frame.Bounds().Dy())
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// WriteGIF is ExportGIF to a file created (or truncated) at path.
+func WriteGIF(path string, g *Game, from, to, every int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := ExportGIF(f, g, from, to, every); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportGIFFrames(t *testing.T) {
+	g := NewGame()
+	var buf bytes.Buffer
+	if err := ExportGIF(&buf, g, 5, 25, 5); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("exported GIF does not decode: %v", err)
+	}
+	if len(anim.Image) != 5 || len(anim.Delay) != 5 {
+		t.Errorf("%d frames and %d delays, want 5 (ticks 5, 10, 15, 20, 25)", len(anim.Image), len(anim.Delay))
+	}
+	if g.TickCounter != 25 {
+		t.Errorf("game at tick %d after export, want 25", g.TickCounter)
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.gif")
+	if err := WriteGIF(path, NewGame(), 0, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 3 {
+		t.Errorf("%d frames, want 3", len(anim.Image))
+	}
+}
+
+func TestExportGIFRejectsBadRange(t *testing.T) {
+	g := NewGame()
+	g.RunTo(10)
+	for _, r := range [][3]int{{5, 20, 1}, {20, 15, 1}, {10, 20, 0}} {
+		if err := ExportGIF(&bytes.Buffer{}, g, r[0], r[1], r[2]); err == nil {
+			t.Errorf("ExportGIF(%d-%d every %d) at tick 10 succeeded", r[0], r[1], r[2])
+		}
+	}
+}
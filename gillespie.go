@@ -0,0 +1,125 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// --- Propensities & Gillespie Mode ---
+
+// Propensity returns the mass-action propensity of reaction i: its rate times
+// the number of distinct reactant combinations (n choose k for a species
+// needed k times) times the count of each catalyst, divided by Volume^(m-1)
+// where m is the number of participating molecules (reactants plus
+// catalysts). Larger ponds therefore dilute bimolecular encounters. It is
+// zero whenever the reaction cannot fire, including when a product has
+// reached its production cap for the tick or would pass its maximum count.
+//
+// A reaction with a RateLaw uses that instead of mass action. The result is
+// still zero when the reaction is disabled or cannot fire, and negative
+// results are treated as zero.
+func (p *Pond) Propensity(i int) float64 {
+	r := p.Reactions[i]
+	if r.MinTotalPopulation > 0 && p.TotalPopulation() < r.MinTotalPopulation || !p.ratioMet(r) || !p.withinCaps(r) || !p.belowMax(r) {
+		return 0
+	}
+	if r.RateLaw != nil {
+		if r.Disabled || !p.canFire(r) {
+			return 0
+		}
+		return math.Max(r.RateLaw(p, r), 0)
+	}
+
+	a := p.InhibitedRate(r)
+	for k, reactant := range r.Reactants {
+		if slices.Index(r.Reactants, reactant) < k {
+			continue // Already counted at its first occurrence
+		}
+		needed := 0
+		for _, other := range r.Reactants[k:] {
+			if other == reactant {
+				needed++
+			}
+		}
+		a *= choose(p.Molecules[reactant], needed)
+	}
+	catalysts := r.AllCatalysts()
+	for _, catalyst := range catalysts {
+		a *= float64(max(p.catalystCount(r, catalyst), 0))
+	}
+	if order := len(r.Reactants) + len(catalysts); order > 1 {
+		a /= math.Pow(p.volume(), float64(order-1))
+	}
+	return a
+}
+
+// choose returns n choose k as a float, 0 when n < k.
+func choose(n, k int) float64 {
+	if n < k {
+		return 0
+	}
+	c := 1.0
+	for i := 0; i < k; i++ {
+		c = c * float64(n-i) / float64(i+1)
+	}
+	return c
+}
+
+// Propensities returns the propensity of every reaction.
+func (p *Pond) Propensities() []float64 {
+	return p.fillPropensities(make([]float64, len(p.Reactions)))
+}
+
+// fillPropensities writes the propensity of every reaction into buf, growing
+// it if needed, and returns it. Used with a scratch buffer so selection does
+// not allocate every step.
+func (p *Pond) fillPropensities(buf []float64) []float64 {
+	buf = slices.Grow(buf[:0], len(p.Reactions))[:len(p.Reactions)]
+	for i := range p.Reactions {
+		buf[i] = p.Propensity(i)
+	}
+	return buf
+}
+
+// TotalPropensity returns the sum of all reaction propensities. Zero means
+// no reaction can fire and the system has stalled.
+func (p *Pond) TotalPropensity() float64 {
+	total := 0.0
+	for i := range p.Reactions {
+		total += p.Propensity(i)
+	}
+	return total
+}
+
+// gillespieSelect picks a reaction in proportion to its propensity and
+// advances the simulated time by an exponentially distributed waiting time
+// (Gillespie's direct method). It returns -1 when nothing can fire.
+func (p *Pond) gillespieSelect() int {
+	if p.propsCached {
+		p.refreshPropensities(p.reactionIndex().volatile)
+	} else {
+		p.weights = p.fillPropensities(p.weights)
+	}
+	props := p.weights
+	total := 0.0
+	for _, a := range props {
+		total += a
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	idx := p.pickWeighted(props)
+	p.Time += p.rng.ExpFloat64() / total
+	return idx
+}
+
+// MichaelisMenten returns a saturating rate law for use as Reaction.RateLaw:
+// vmax * S / (km + S), where S is the count of substrate. It grows linearly
+// with S while S is well below km and levels off at vmax above it.
+func MichaelisMenten(vmax, km float64, substrate string) func(p *Pond, r Reaction) float64 {
+	return func(p *Pond, r Reaction) float64 {
+		s := float64(p.Molecules[substrate])
+		return vmax * s / (km + s)
+	}
+}
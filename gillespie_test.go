@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTotalPropensity(t *testing.T) {
+	p := NewPondWithSeed(1)
+	p.Gillespie = true
+	for i := 0; i < 1000; i++ {
+		p.Step()
+		sum := 0.0
+		for j := range p.Reactions {
+			sum += p.Propensity(j)
+		}
+		if total := p.TotalPropensity(); math.Abs(total-sum) > 1e-9*math.Max(sum, 1) {
+			t.Fatalf("step %d: TotalPropensity() = %v, sum of propensities %v", i, total, sum)
+		}
+	}
+}
+
+func TestTotalPropensityZeroWhenNothingEligible(t *testing.T) {
+	p := NewPondWithSeed(1)
+	for name := range p.Molecules {
+		p.Molecules[name] = 0
+	}
+	if total := p.TotalPropensity(); total != 0 {
+		t.Errorf("TotalPropensity() = %v with an empty pond, want 0", total)
+	}
+}
+
+func TestMichaelisMentenRateLaw(t *testing.T) {
+	p := testPond(1, map[string]int{"S": 0, "P": 0},
+		Reaction{Reactants: []string{"S"}, Product: "P", RateLaw: MichaelisMenten(10, 50, "S")})
+
+	p.Molecules["S"] = 5 // Well below km: close to linear, vmax*S/km
+	if a := p.Propensity(0); math.Abs(a-50.0/55) > 1e-9 {
+		t.Errorf("propensity at S = 5 is %v, want %v", a, 50.0/55)
+	}
+	p.Molecules["S"] = 1000000 // Far above km: saturated near vmax
+	if a := p.Propensity(0); a < 9.99 || a > 10 {
+		t.Errorf("propensity at S = 1e6 is %v, want just below 10", a)
+	}
+	p.Molecules["S"] = 0
+	if a := p.Propensity(0); a != 0 {
+		t.Errorf("propensity without substrate is %v, want 0", a)
+	}
+}
+
+func TestSelectorUsesRateLaw(t *testing.T) {
+	constant := func(a float64) func(*Pond, Reaction) float64 {
+		return func(*Pond, Reaction) float64 { return a }
+	}
+	p := testPond(1, map[string]int{"A": 100000, "C": 100000},
+		Reaction{Reactants: []string{"A"}, Product: "B", RateLaw: constant(0)},
+		Reaction{Reactants: []string{"C"}, Product: "D", RateLaw: constant(1)},
+	)
+	p.Gillespie = true
+	for i := 0; i < 1000; i++ {
+		p.Step()
+	}
+	if p.Molecules["B"] != 0 || p.Molecules["D"] != 1000 {
+		t.Errorf("B = %d, D = %d; want only the reaction with a positive rate law to fire", p.Molecules["B"], p.Molecules["D"])
+	}
+}
@@ -5,6 +5,7 @@ go 1.25.0
 require (
 	github.com/hajimehoshi/ebiten/v2 v2.9.3
 	golang.org/x/image v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Live Time-Series Graph ---
+
+// plottedSeries returns the available species that are selected for plotting,
+// in the order they are available.
+func plottedSeries(selection map[string]bool, available []string) []string {
+	series := []string{}
+	for _, name := range available {
+		if selection[name] {
+			series = append(series, name)
+		}
+	}
+	return series
+}
+
+// drawGraph plots the count history of each selected species as a line inside area.
+func (g *Game) drawGraph(screen *ebiten.Image, area image.Rectangle) {
+	graphX, graphY := float32(area.Min.X), float32(area.Min.Y)
+	graphWidth, graphHeight := float32(area.Dx()), float32(area.Dy())
+	vector.StrokeRect(screen, graphX, graphY, graphWidth, graphHeight, 1, color.RGBA{80, 80, 80, 255}, false)
+
+	points := g.History.Points()
+	if len(points) < 2 {
+		return
+	}
+
+	names := plottedSeries(g.GraphSelection, g.Pond.SpeciesNames())
+
+	// A shared y-axis, scaled to the highest peak of the plotted species
+	maxCount := 1
+	for _, name := range names {
+		if g.HighWater[name] > maxCount {
+			maxCount = g.HighWater[name]
+		}
+	}
+	text.Draw(screen, strconv.Itoa(maxCount), basicfont.Face7x13, area.Min.X+4, area.Min.Y+14, g.Theme.Dim)
+
+	xStep := graphWidth / float32(len(points)-1)
+	yScale := graphHeight / float32(maxCount)
+	draw := g.renderer()
+	for _, name := range names {
+		clr := g.Theme.SpeciesColor(name)
+
+		// Faint line at the species' all-time peak
+		peak := clr
+		peak.A = 60
+		yPeak := graphY + graphHeight - float32(g.HighWater[name])*yScale
+		draw.strokeLine(screen, graphX, yPeak, graphX+graphWidth, yPeak, peak)
+
+		for i := 1; i < len(points); i++ {
+			x0 := graphX + float32(i-1)*xStep
+			x1 := graphX + float32(i)*xStep
+			y0 := graphY + graphHeight - float32(points[i-1].Last[name])*yScale
+			y1 := graphY + graphHeight - float32(points[i].Last[name])*yScale
+			draw.strokeLine(screen, x0, y0, x1, y1, clr)
+		}
+	}
+
+	cx, cy := ebiten.CursorPosition()
+	if i, ok := graphIndexAt(cx, cy, area, len(points)); ok {
+		g.drawGraphTooltip(screen, area, graphX+float32(i)*xStep, cx, cy, points[i], names)
+	}
+}
+
+// graphIndexAt maps a cursor position to the nearest of n history points
+// spread evenly across area, as drawGraph plots them. It reports false when
+// the cursor is outside area or there is no line to hover.
+func graphIndexAt(x, y int, area image.Rectangle, n int) (int, bool) {
+	if n < 2 || !image.Pt(x, y).In(area) {
+		return 0, false
+	}
+	step := float64(area.Dx()) / float64(n-1)
+	i := int(math.Round(float64(x-area.Min.X) / step))
+	return min(max(i, 0), n-1), true
+}
+
+// tooltipLines lists the tick of a history point and the count of each
+// plotted species at its last tick.
+func tooltipLines(point HistoryPoint, names []string) []string {
+	lines := []string{fmt.Sprintf("Tick %d", point.Tick+point.Span-1)}
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %d", name, point.Last[name]))
+	}
+	return lines
+}
+
+// drawGraphTooltip draws a cursor line at x and the point's tooltip in a box
+// beside the cursor, flipped to the left near the right edge.
+func (g *Game) drawGraphTooltip(screen *ebiten.Image, area image.Rectangle, x float32, cx, cy int, point HistoryPoint, names []string) {
+	lines := tooltipLines(point, names)
+	vector.StrokeLine(screen, x, float32(area.Min.Y), x, float32(area.Max.Y), 1, g.Theme.Dim, false)
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line)*7) // basicfont glyphs are 7 pixels wide
+	}
+	width += 10
+	height := 6 + 14*len(lines)
+	left := cx + 12
+	if left+width > ScreenWidth {
+		left = cx - 12 - width
+	}
+	top := min(cy, ScreenHeight-height)
+	vector.FillRect(screen, float32(left), float32(top), float32(width), float32(height), color.RGBA{20, 20, 40, 230}, false)
+	vector.StrokeRect(screen, float32(left), float32(top), float32(width), float32(height), 1, g.Theme.Dim, false)
+	for i, line := range lines {
+		clr := g.Theme.Dim
+		if i > 0 {
+			clr = g.Theme.SpeciesColor(names[i-1])
+		}
+		text.Draw(screen, line, basicfont.Face7x13, left+5, top+15+14*i, clr)
+	}
+}
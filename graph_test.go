@@ -0,0 +1,67 @@
+package main
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestPlottedSeries(t *testing.T) {
+	available := []string{"A", "B", "C", "D", "E"}
+	tests := []struct {
+		name      string
+		selection map[string]bool
+		want      []string
+	}{
+		{"default", map[string]bool{"D": true, "E": true}, []string{"D", "E"}},
+		{"available order", map[string]bool{"E": true, "A": true}, []string{"A", "E"}},
+		{"deselected", map[string]bool{"D": false, "E": true}, []string{"E"}},
+		{"unknown species", map[string]bool{"Z": true, "B": true}, []string{"B"}},
+		{"nothing selected", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := plottedSeries(tt.selection, available); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plottedSeries(%v) = %v, want %v", tt.selection, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphIndexAt(t *testing.T) {
+	area := image.Rect(100, 50, 300, 150) // 5 points, 50 pixels apart
+	tests := []struct {
+		name   string
+		x, y   int
+		n      int
+		want   int
+		inside bool
+	}{
+		{"left edge", 100, 100, 5, 0, true},
+		{"rounds down", 124, 60, 5, 0, true},
+		{"rounds up", 126, 60, 5, 1, true},
+		{"middle", 200, 149, 5, 2, true},
+		{"right edge", 299, 50, 5, 4, true},
+		{"left of graph", 99, 100, 5, 0, false},
+		{"below graph", 200, 150, 5, 0, false},
+		{"above graph", 200, 49, 5, 0, false},
+		{"single point", 200, 100, 1, 0, false},
+		{"empty history", 200, 100, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, inside := graphIndexAt(tt.x, tt.y, area, tt.n)
+			if got != tt.want || inside != tt.inside {
+				t.Errorf("graphIndexAt(%d, %d) = %d, %t; want %d, %t", tt.x, tt.y, got, inside, tt.want, tt.inside)
+			}
+		})
+	}
+}
+
+func TestTooltipLines(t *testing.T) {
+	point := HistoryPoint{Tick: 41, Span: 4, Last: map[string]int{"D": 12, "E": 300}}
+	want := []string{"Tick 44", "E: 300", "D: 12", "Z: 0"}
+	if got := tooltipLines(point, []string{"E", "D", "Z"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("tooltipLines() = %q, want %q", got, want)
+	}
+}
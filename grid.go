@@ -0,0 +1,79 @@
+package main
+
+// --- Spatial Grid ---
+
+// Grid is a spatial pond: a Width x Height lattice of cells, each a Pond
+// with its own counts and random source, all running the same reactions.
+// Each cell's neighbours are the up to four cells sharing an edge with it;
+// the lattice does not wrap, so edge and corner cells have fewer neighbours.
+type Grid struct {
+	Width, Height int
+	Cells         []*Pond // Row-major: cell (x, y) is Cells[y*Width+x]
+
+	rng *cloneableRand // Picks the cell each step acts on
+}
+
+// NewGrid creates a grid of empty cells sharing the given reactions. Cell i
+// is seeded with seed+1+i; the grid's own cell picker uses seed.
+func NewGrid(width, height int, reactions []Reaction, seed int64) *Grid {
+	g := &Grid{Width: width, Height: height, rng: newCloneableRand(seed)}
+	for i := 0; i < width*height; i++ {
+		cellSeed := seed + 1 + int64(i)
+		g.Cells = append(g.Cells, &Pond{
+			Seed:      cellSeed,
+			Molecules: map[string]int{},
+			Reactions: reactions,
+			Status:    message(MsgInitialized),
+			rng:       newCloneableRand(cellSeed),
+		})
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := g.Cell(x, y)
+			for _, d := range [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+				if n := g.Cell(x+d[0], y+d[1]); n != nil {
+					cell.neighbors = append(cell.neighbors, n)
+				}
+			}
+		}
+	}
+	return g
+}
+
+// Cell returns the cell at (x, y), or nil outside the grid.
+func (g *Grid) Cell(x, y int) *Pond {
+	if x < 0 || y < 0 || x >= g.Width || y >= g.Height {
+		return nil
+	}
+	return g.Cells[y*g.Width+x]
+}
+
+// TotalCount returns the count of species summed over all cells. Moving
+// molecules between cells leaves it unchanged; only reactions alter it.
+func (g *Grid) TotalCount(species string) int {
+	total := 0
+	for _, cell := range g.Cells {
+		total += cell.Molecules[species]
+	}
+	return total
+}
+
+// Step picks a cell uniformly at random and steps it.
+func (g *Grid) Step() {
+	if len(g.Cells) == 0 {
+		return
+	}
+	g.Cells[g.rng.Intn(len(g.Cells))].Step()
+}
+
+// catalystCount returns how many units of catalyst r can use: the pond's own
+// count, plus that of its neighbouring cells when r is NeighborCatalyzed.
+func (p *Pond) catalystCount(r Reaction, catalyst string) int {
+	n := p.Molecules[catalyst]
+	if r.NeighborCatalyzed {
+		for _, cell := range p.neighbors {
+			n += max(cell.Molecules[catalyst], 0)
+		}
+	}
+	return n
+}
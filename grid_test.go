@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+func TestNeighborCatalysis(t *testing.T) {
+	tests := []struct {
+		name              string
+		neighborCatalyzed bool
+		catalystAt        int // Cell of the 3x1 grid holding the catalyst
+		fires             bool
+	}{
+		{"catalyst in neighbour", true, 1, true},
+		{"catalyst in own cell", true, 0, true},
+		{"catalyst two cells away", true, 2, false},
+		{"local reaction ignores neighbours", false, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Reaction{Reactants: []string{"A"}, Product: "B", Catalysts: []string{"X"}, NeighborCatalyzed: tt.neighborCatalyzed}
+			g := NewGrid(3, 1, []Reaction{r}, 1)
+			cell := g.Cell(0, 0)
+			cell.Molecules["A"] = 10
+			g.Cells[tt.catalystAt].Molecules["X"] = 1
+
+			for i := 0; i < 5; i++ {
+				cell.Step()
+			}
+			if fired := cell.Molecules["B"] > 0; fired != tt.fires {
+				t.Errorf("fired = %t, want %t", fired, tt.fires)
+			}
+			if g.TotalCount("X") != 1 {
+				t.Errorf("catalyst consumed: %d left", g.TotalCount("X"))
+			}
+		})
+	}
+}
+
+func TestGridNeighbours(t *testing.T) {
+	g := NewGrid(3, 3, nil, 1)
+	for _, tt := range []struct{ x, y, want int }{{0, 0, 2}, {1, 0, 3}, {1, 1, 4}, {2, 2, 2}} {
+		if n := len(g.Cell(tt.x, tt.y).neighbors); n != tt.want {
+			t.Errorf("cell (%d, %d) has %d neighbours, want %d", tt.x, tt.y, n, tt.want)
+		}
+	}
+	if g.Cell(3, 0) != nil || g.Cell(0, -1) != nil {
+		t.Error("Cell outside the grid is not nil")
+	}
+}
+
+func TestGridReactionMassBalance(t *testing.T) {
+	reactions := []Reaction{
+		{Reactants: []string{"A", "B"}, Product: "C", Catalysts: []string{"X"}, NeighborCatalyzed: true},
+		{Reactants: []string{"C"}, Product: "D", ProductYield: 2},
+		{Reactants: []string{"D"}, Product: "A"},
+	}
+	// Net change of each species per fire of each reaction
+	stoichiometry := map[string][]int{
+		"A": {-1, 0, 1},
+		"B": {-1, 0, 0},
+		"C": {1, -1, 0},
+		"D": {0, 2, -1},
+		"X": {0, 0, 0},
+	}
+	g := NewGrid(3, 3, reactions, 1)
+	for i, cell := range g.Cells {
+		cell.Molecules["A"], cell.Molecules["B"] = 50+i, 80
+	}
+	g.Cell(1, 1).Molecules["X"] = 1
+
+	before := map[string]int{}
+	for species := range stoichiometry {
+		before[species] = g.TotalCount(species)
+	}
+	var fires []int
+	for tick := 0; tick < 20; tick++ {
+		for i := 0; i < DefaultStepsPerTick; i++ {
+			g.Step()
+		}
+		fires = make([]int, len(reactions))
+		for _, cell := range g.Cells {
+			for r, n := range cell.FireCounts {
+				fires[r] += n
+			}
+		}
+		for species, change := range stoichiometry {
+			want := before[species]
+			for r, n := range fires {
+				want += change[r] * n
+			}
+			if got := g.TotalCount(species); got != want {
+				t.Fatalf("tick %d: total %s = %d, want %d from fires %v", tick, species, got, want, fires)
+			}
+		}
+	}
+	if fires[0] == 0 || fires[1] == 0 || fires[2] == 0 {
+		t.Errorf("fires %v, want every reaction exercised", fires)
+	}
+}
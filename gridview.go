@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Grid View ---
+
+// DefaultPourAmount is how many molecules one click pours into a cell.
+const DefaultPourAmount = 50
+
+// gridViewArea is the screen region the grid is fitted into.
+var gridViewArea = image.Rect(20, 60, ScreenWidth-20, ScreenHeight-20)
+
+// GridGame implements ebiten.Game for a spatial grid: each cell is shaded by
+// its count of the selected species, and clicking a cell pours that species
+// into it.
+type GridGame struct {
+	Grid         *Grid
+	TickCounter  int
+	StepsPerTick int      // Steps per cell per tick; a tick runs StepsPerTick*cells grid steps
+	Species      []string // Species that can be shown and poured, cycled with Tab
+	Selected     int      // Index into Species
+	PourAmount   int      // Molecules added per click
+	Theme        Theme
+}
+
+// NewGridGame creates a width x height grid running the config's reactions,
+// every cell starting with the config's counts.
+func NewGridGame(cfg *Config, width, height int) *GridGame {
+	grid := NewGrid(width, height, cfg.Reactions, cfg.Seed)
+	for _, cell := range grid.Cells {
+		for name, count := range cfg.Molecules {
+			cell.Molecules[name] = count
+		}
+	}
+	return &GridGame{
+		Grid:         grid,
+		StepsPerTick: cfg.StepsPerTick,
+		Species:      (&Pond{Molecules: cfg.Molecules, Reactions: cfg.Reactions}).networkSpecies(),
+		PourAmount:   DefaultPourAmount,
+		Theme:        themes[DefaultTheme],
+	}
+}
+
+// species returns the selected species, or "" when there are none.
+func (g *GridGame) species() string {
+	if len(g.Species) == 0 {
+		return ""
+	}
+	return g.Species[g.Selected%len(g.Species)]
+}
+
+// gridBounds returns the largest square-celled rectangle for a width x
+// height grid that fits in area, anchored at its top-left corner.
+func gridBounds(area image.Rectangle, width, height int) image.Rectangle {
+	if width <= 0 || height <= 0 {
+		return image.Rectangle{Min: area.Min, Max: area.Min}
+	}
+	size := min(area.Dx()/width, area.Dy()/height)
+	return image.Rectangle{Min: area.Min, Max: area.Min.Add(image.Pt(size*width, size*height))}
+}
+
+// gridCellAt maps a cursor position to the cell of a width x height grid
+// drawn over bounds. It reports false when the cursor is outside the grid.
+func gridCellAt(x, y int, bounds image.Rectangle, width, height int) (int, int, bool) {
+	if width <= 0 || height <= 0 || !image.Pt(x, y).In(bounds) {
+		return 0, 0, false
+	}
+	cx := (x - bounds.Min.X) * width / bounds.Dx()
+	cy := (y - bounds.Min.Y) * height / bounds.Dy()
+	return cx, cy, true
+}
+
+// Update handles pouring and species selection, then runs one tick.
+func (g *GridGame) Update() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) && len(g.Species) > 0 {
+		g.Selected = (g.Selected + 1) % len(g.Species)
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		bounds := gridBounds(gridViewArea, g.Grid.Width, g.Grid.Height)
+		if cx, cy, ok := gridCellAt(x, y, bounds, g.Grid.Width, g.Grid.Height); ok && g.species() != "" {
+			g.Grid.Cell(cx, cy).Molecules[g.species()] += g.PourAmount
+		}
+	}
+
+	for i := 0; i < g.StepsPerTick*len(g.Grid.Cells); i++ {
+		g.Grid.Step()
+	}
+	g.TickCounter++
+	return nil
+}
+
+// Draw shades each cell by its share of the busiest cell's count of the
+// selected species.
+func (g *GridGame) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+	name := g.species()
+	status := fmt.Sprintf("Sim Ticks: %d | Showing and pouring: %s (Tab to change, click to add %d)", g.TickCounter, name, g.PourAmount)
+	text.Draw(screen, status, basicfont.Face7x13, 20, 30, color.White)
+
+	peak := 0
+	for _, cell := range g.Grid.Cells {
+		peak = max(peak, cell.Molecules[name])
+	}
+	text.Draw(screen, fmt.Sprintf("Peak %s per cell: %d | Total: %d", name, peak, g.Grid.TotalCount(name)), basicfont.Face7x13, 20, 50, g.Theme.Dim)
+
+	bounds := gridBounds(gridViewArea, g.Grid.Width, g.Grid.Height)
+	if bounds.Empty() {
+		return
+	}
+	size := float32(bounds.Dx() / g.Grid.Width)
+	base := g.Theme.SpeciesColor(name)
+	for y := 0; y < g.Grid.Height; y++ {
+		for x := 0; x < g.Grid.Width; x++ {
+			share := 0.0
+			if peak > 0 {
+				share = float64(g.Grid.Cell(x, y).Molecules[name]) / float64(peak)
+			}
+			shade := color.RGBA{uint8(float64(base.R) * share), uint8(float64(base.G) * share), uint8(float64(base.B) * share), 255}
+			px := float32(bounds.Min.X) + float32(x)*size
+			py := float32(bounds.Min.Y) + float32(y)*size
+			vector.FillRect(screen, px, py, size, size, shade, false)
+			vector.StrokeRect(screen, px, py, size, size, 1, color.RGBA{40, 40, 40, 255}, false)
+		}
+	}
+}
+
+// Layout returns the screen dimensions.
+func (g *GridGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return ScreenWidth, ScreenHeight
+}
+
+// runGridGUI opens the window and runs the grid view until it is closed.
+func runGridGUI(game *GridGame) error {
+	ebiten.SetWindowSize(ScreenWidth, ScreenHeight)
+	ebiten.SetWindowTitle("Go Autocatalytic Set - Grid")
+
+	return ebiten.RunGame(game)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestGridBounds(t *testing.T) {
+	area := image.Rect(20, 60, 420, 260) // 400 x 200
+	tests := []struct {
+		width, height int
+		want          image.Rectangle
+	}{
+		{4, 2, image.Rect(20, 60, 420, 260)},   // 100-pixel cells fill the area
+		{10, 10, image.Rect(20, 60, 220, 260)}, // Height-limited
+		{3, 1, image.Rect(20, 60, 419, 193)},   // 133-pixel cells
+		{0, 5, image.Rect(20, 60, 20, 60)},
+	}
+	for _, tt := range tests {
+		if got := gridBounds(area, tt.width, tt.height); got != tt.want {
+			t.Errorf("gridBounds(%dx%d) = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestGridCellAt(t *testing.T) {
+	bounds := image.Rect(20, 60, 420, 260) // A 4 x 2 grid of 100-pixel cells
+	tests := []struct {
+		name   string
+		x, y   int
+		cx, cy int
+		inside bool
+	}{
+		{"top-left corner", 20, 60, 0, 0, true},
+		{"first cell", 119, 159, 0, 0, true},
+		{"next column", 120, 60, 1, 0, true},
+		{"next row", 20, 160, 0, 1, true},
+		{"bottom-right corner", 419, 259, 3, 1, true},
+		{"left of grid", 19, 100, 0, 0, false},
+		{"above grid", 100, 59, 0, 0, false},
+		{"right of grid", 420, 100, 0, 0, false},
+		{"below grid", 100, 260, 0, 0, false},
+		{"far away", -50, 1000, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cx, cy, inside := gridCellAt(tt.x, tt.y, bounds, 4, 2)
+			if cx != tt.cx || cy != tt.cy || inside != tt.inside {
+				t.Errorf("gridCellAt(%d, %d) = %d, %d, %t; want %d, %d, %t", tt.x, tt.y, cx, cy, inside, tt.cx, tt.cy, tt.inside)
+			}
+		})
+	}
+	if _, _, inside := gridCellAt(100, 100, bounds, 0, 0); inside {
+		t.Error("gridCellAt reported a cell of an empty grid")
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestRepeatedReactantNeedsEveryUnit(t *testing.T) {
+	for _, gillespie := range []bool{false, true} {
+		p := testPond(1, map[string]int{"A": 1, "B": 0}, Reaction{Reactants: []string{"A", "A"}, Product: "B"})
+		p.Gillespie = gillespie
+		p.Run(100)
+		if p.Molecules["A"] != 1 || p.Molecules["B"] != 0 || p.TotalFires() != 0 {
+			t.Errorf("gillespie %t: 2A -> B with one A left A = %d, B = %d", gillespie, p.Molecules["A"], p.Molecules["B"])
+		}
+	}
+
+	p := testPond(1, map[string]int{"A": 2, "B": 0}, Reaction{Reactants: []string{"A", "A"}, Product: "B"})
+	p.Run(100)
+	if p.Molecules["A"] != 0 || p.Molecules["B"] != 1 {
+		t.Errorf("2A -> B with two A's left A = %d, B = %d; want 0, 1", p.Molecules["A"], p.Molecules["B"])
+	}
+}
+
+func TestGuardedFireRefusesShortReactants(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 1, "B": 0}, Reaction{Reactants: []string{"A", "A"}, Product: "B"})
+	p.Guarded = true
+	p.fire(0) // Bypasses canFire, as a buggy caller would
+	if p.Molecules["A"] != 1 || p.Molecules["B"] != 0 {
+		t.Errorf("guarded fire left A = %d, B = %d; want 1, 0", p.Molecules["A"], p.Molecules["B"])
+	}
+	if p.GuardErrors != 1 || p.TotalFires() != 0 {
+		t.Errorf("%d guard errors and %d fires, want 1 and 0", p.GuardErrors, p.TotalFires())
+	}
+
+	if err := p.checkReactants(p.Reactions[0]); err == nil {
+		t.Error("checkReactants accepted one A for 2A")
+	}
+	p.Molecules["A"] = 2
+	if err := p.checkReactants(p.Reactions[0]); err != nil {
+		t.Errorf("checkReactants with two A's: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import "math"
+
+// --- Half-Life Decay ---
+
+// DecayProbability converts a half-life in ticks to the probability that a
+// molecule decays during one tick, 1 - 2^(-1/halfLife), so that half of a
+// population is left after halfLife ticks on average.
+func DecayProbability(halfLife float64) float64 {
+	if halfLife <= 0 {
+		return 0
+	}
+	return 1 - math.Pow(2, -1/halfLife)
+}
+
+// Decay runs one tick of every enabled half-life reaction: each molecule of
+// its reactant decays with the per-tick probability, firing the reaction
+// once per decayed molecule. The draws come from each reaction's own
+// stream, so they never shift the main one.
+func (p *Pond) Decay() {
+	for i, r := range p.Reactions {
+		if r.HalfLife <= 0 || r.Disabled || len(r.Reactants) != 1 {
+			continue
+		}
+		prob := DecayProbability(r.HalfLife)
+		rng := p.reactionRand(i)
+		decayed := 0
+		for k := p.Molecules[r.Reactants[0]]; k > 0; k-- {
+			if rng.Float64() < prob {
+				decayed++
+			}
+		}
+		for ; decayed > 0; decayed-- {
+			p.fire(i)
+		}
+	}
+}
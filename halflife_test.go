@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecayProbability(t *testing.T) {
+	for _, tt := range []struct{ halfLife, want float64 }{
+		{1, 0.5},
+		{2, 1 - math.Sqrt(0.5)},
+		{10, 1 - math.Pow(2, -0.1)},
+		{0, 0},
+		{-3, 0},
+	} {
+		if got := DecayProbability(tt.halfLife); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("DecayProbability(%v) = %v, want %v", tt.halfLife, got, tt.want)
+		}
+	}
+}
+
+func TestHalfLifeHalvesPopulation(t *testing.T) {
+	const initial, trials = 1000, 20
+	total := 0
+	for seed := int64(1); seed <= trials; seed++ {
+		p := testPond(seed, map[string]int{"X": initial, "Y": 0},
+			Reaction{Reactants: []string{"X"}, Product: "Y", HalfLife: 10})
+		for tick := 0; tick < 10; tick++ {
+			p.Decay()
+		}
+		if got := p.Molecules["X"] + p.Molecules["Y"]; got != initial {
+			t.Fatalf("seed %d: X + Y = %d, want %d", seed, got, initial)
+		}
+		total += p.Molecules["X"]
+	}
+	if mean := float64(total) / trials; math.Abs(mean-initial/2) > 15 {
+		t.Errorf("mean X after one half-life = %.1f, want about %d", mean, initial/2)
+	}
+}
+
+func TestHalfLifeReactionIsNotStepped(t *testing.T) {
+	p := testPond(1, map[string]int{"X": 100, "Y": 0},
+		Reaction{Reactants: []string{"X"}, Product: "Y", HalfLife: 10})
+	p.Run(1000)
+	if p.Molecules["X"] != 100 {
+		t.Errorf("X = %d after stepping, want 100: half-life decay only runs per tick", p.Molecules["X"])
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// --- Headless Runner ---
+
+// progressInterval is how often (in percent of the run) progress is reported.
+const progressInterval = 5
+
+// estimateETA derives step throughput and the remaining time from the steps
+// completed so far, the total number of steps, and the time elapsed.
+// The caller decides the window: passing the most recent interval gives an
+// ETA based on recent throughput rather than the whole-run average.
+func estimateETA(done, total int, elapsed time.Duration) (stepsPerSec float64, eta time.Duration) {
+	if done <= 0 || elapsed <= 0 {
+		return 0, 0
+	}
+	stepsPerSec = float64(done) / elapsed.Seconds()
+	remaining := total - done
+	if remaining <= 0 {
+		return stepsPerSec, 0
+	}
+	eta = time.Duration(float64(remaining) / stepsPerSec * float64(time.Second))
+	return stepsPerSec, eta
+}
+
+// startupBanner summarizes the configured experiment before a headless run and
+// flags chemistries that cannot produce the target or have no autocatalysis.
+func startupBanner(g *Game, target string) string {
+	p := g.Pond
+	var b strings.Builder
+	fmt.Fprintf(&b, "Seed: %d\n", p.Seed)
+	fmt.Fprintf(&b, "Species: %d | Reactions: %d | Steps/Tick: %d\n", len(p.Molecules), len(p.Reactions), g.StepsPerTick)
+	fmt.Fprintf(&b, "Network: %s\n", p.NetworkStats())
+
+	reachable := p.Reachable()[target]
+	fmt.Fprintf(&b, "Target %s reachable: %t\n", target, reachable)
+
+	var warnings []string
+	if !reachable {
+		warnings = append(warnings, fmt.Sprintf("target species %s is unreachable from the initial molecules", target))
+	}
+	autocatalytic := false
+	for _, r := range p.Reactions {
+		if r.IsAutocatalytic() {
+			autocatalytic = true
+			break
+		}
+	}
+	if !autocatalytic {
+		warnings = append(warnings, "no autocatalytic reaction is defined")
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(&b, "WARNING: %s\n", w)
+	}
+	return b.String()
+}
+
+// DefaultExtinctGrace is how many ticks a species must stay at zero before
+// -until-extinct counts it as extinct.
+const DefaultExtinctGrace = 10
+
+// extinctionWatch detects when a species dies out: its count must reach zero
+// and stay there for grace ticks, so a count that touches zero and recovers
+// does not stop the run.
+type extinctionWatch struct {
+	species   string
+	grace     int
+	zeroSince int // First tick of the current run of zero counts, or -1
+}
+
+// newExtinctionWatch watches species in p with the given grace period in
+// ticks. A species that is neither in the pond nor in its reaction network
+// is rejected: its count would be zero from the start, so a typo would stop
+// the run at once and report an extinction.
+func newExtinctionWatch(p *Pond, species string, grace int) (*extinctionWatch, error) {
+	if !slices.Contains(p.networkSpecies(), species) {
+		return nil, fmt.Errorf("-until-extinct: unknown species %q", species)
+	}
+	return &extinctionWatch{species: species, grace: max(grace, 0), zeroSince: -1}, nil
+}
+
+// observe records the species' count at tick and reports the extinction tick
+// (the first tick of the zero run) once the run has lasted the grace period.
+func (w *extinctionWatch) observe(tick, count int) (int, bool) {
+	if count > 0 {
+		w.zeroSince = -1
+		return 0, false
+	}
+	if w.zeroSince < 0 {
+		w.zeroSince = tick
+	}
+	return w.zeroSince, tick-w.zeroSince >= w.grace
+}
+
+// runHeadless steps the simulation without a window for a bounded number of steps.
+// Steps are grouped into ticks of g.StepsPerTick so the trajectory matches the GUI.
+// With a non-nil watch the run also stops once its species has gone extinct.
+func runHeadless(g *Game, totalSteps int, quiet bool, watch *extinctionWatch) {
+	if !quiet {
+		fmt.Print(startupBanner(g, "E"))
+	}
+
+	start := time.Now()
+	lastReport := start
+	lastReportSteps := 0
+	reportEvery := totalSteps * progressInterval / 100
+	if reportEvery < 1 {
+		reportEvery = 1
+	}
+
+	done := 0
+	for done < totalSteps {
+		n := g.StepsPerTick
+		if totalSteps-done < n {
+			n = totalSteps - done
+		}
+		if n <= 0 {
+			break
+		}
+		g.advance(n)
+		done += n
+
+		if watch != nil {
+			if tick, extinct := watch.observe(g.TickCounter, g.Pond.Molecules[watch.species]); extinct {
+				fmt.Printf("%s went extinct at tick %d (stopped at tick %d)\n", watch.species, tick, g.TickCounter)
+				break
+			}
+		}
+
+		if !quiet && done-lastReportSteps >= reportEvery && done < totalSteps {
+			now := time.Now()
+			// Throughput over the last interval only, so the ETA tracks slowdowns
+			rate, eta := estimateETA(done-lastReportSteps, totalSteps-lastReportSteps, now.Sub(lastReport))
+			fmt.Printf("[%5.1f%%] steps %d/%d | tick %d | %.0f steps/s | ETA %s\n",
+				100*float64(done)/float64(totalSteps), done, totalSteps, g.TickCounter, rate, eta.Round(time.Second))
+			lastReport = now
+			lastReportSteps = done
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("Finished %d steps (%d ticks) in %s\n", done, g.TickCounter, time.Since(start).Round(time.Millisecond))
+	}
+	printCounts(g.Pond)
+	if len(g.Pond.Replicators()) > 1 {
+		leader, share := g.Pond.LeadingReplicator()
+		fmt.Printf("Leading replicator: %s (%.1f%% of replicators)\n", leader, 100*share)
+	}
+	fmt.Printf("Dead reactions (never fired): %s\n", reactionLabels(g.Pond.DeadReactions()))
+	fmt.Printf("Reaction coverage: %.1f%%\n", g.Pond.Coverage())
+	fmt.Printf("Dominance fraction: %.3f (%s)\n", g.Pond.DominanceFraction(), strings.Join(g.Pond.AutocatalyticSpecies(), ", "))
+}
+
+// printCounts writes the final molecule counts in a stable order.
+func printCounts(p *Pond) {
+	for _, name := range p.SpeciesNames() {
+		fmt.Printf("%s: %d\n", name, p.Molecules[name])
+	}
+}
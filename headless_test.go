@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateETA(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int
+		elapsed     time.Duration
+		wantRate    float64
+		wantETA     time.Duration
+	}{
+		{"a third done", 100, 300, time.Second, 100, 2 * time.Second},
+		{"half done", 500, 1000, 2 * time.Second, 250, 2 * time.Second},
+		{"finished", 300, 300, time.Second, 300, 0},
+		{"nothing done", 0, 300, time.Second, 0, 0},
+		{"no time elapsed", 100, 300, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, eta := estimateETA(tt.done, tt.total, tt.elapsed)
+			if rate != tt.wantRate || eta != tt.wantETA {
+				t.Errorf("estimateETA(%d, %d, %v) = %v, %v; want %v, %v",
+					tt.done, tt.total, tt.elapsed, rate, eta, tt.wantRate, tt.wantETA)
+			}
+		})
+	}
+}
+
+func TestStartupBanner(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(4242))
+	banner := startupBanner(g, "E")
+	if !strings.Contains(banner, "Seed: 4242") {
+		t.Errorf("banner does not show the seed:\n%s", banner)
+	}
+	if strings.Contains(banner, "WARNING") {
+		t.Errorf("default chemistry flagged:\n%s", banner)
+	}
+
+	g.Pond.Reactions = g.Pond.Reactions[:2] // Drop the autocatalytic reaction
+	banner = startupBanner(g, "E")
+	if !strings.Contains(banner, "WARNING: no autocatalytic reaction is defined") {
+		t.Errorf("banner does not flag the missing autocatalysis:\n%s", banner)
+	}
+
+	if banner := startupBanner(g, "Z"); !strings.Contains(banner, "WARNING: target species Z is unreachable") {
+		t.Errorf("banner does not flag the unreachable target:\n%s", banner)
+	}
+}
+
+func TestUntilExtinctStopsAtExtinction(t *testing.T) {
+	// 250 A's at 100 steps a tick: gone by the end of tick 3
+	g := newGameWithPond(testPond(1, map[string]int{"A": 250, "B": 0}, Reaction{Reactants: []string{"A"}, Product: "B"}))
+	watch, err := newExtinctionWatch(g.Pond, "A", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runHeadless(g, 1000000, true, watch)
+	if g.TickCounter != 13 || watch.zeroSince != 3 {
+		t.Errorf("stopped at tick %d with A extinct since tick %d, want tick 13 after extinction at tick 3", g.TickCounter, watch.zeroSince)
+	}
+}
+
+func TestUntilExtinctRunsToStepLimit(t *testing.T) {
+	g := newGameWithPond(testPond(1, map[string]int{"A": 1000, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"B"}, Product: "A"},
+	))
+	watch, err := newExtinctionWatch(g.Pond, "A", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runHeadless(g, 5000, true, watch)
+	if g.Pond.StepCount != 5000 || g.TickCounter != 50 {
+		t.Errorf("ran %d steps in %d ticks, want all 5000 in 50", g.Pond.StepCount, g.TickCounter)
+	}
+}
+
+func TestExtinctionWatchGrace(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 1})
+	w, err := newExtinctionWatch(p, "A", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range []struct {
+		tick, count int
+		extinct     bool
+	}{
+		{1, 5, false},
+		{2, 0, false},
+		{3, 2, false}, // Recovered: the zero run starts over
+		{4, 0, false},
+		{5, 0, false},
+		{6, 0, true},
+	} {
+		if tick, extinct := w.observe(step.tick, step.count); extinct != step.extinct || extinct && tick != 4 {
+			t.Errorf("tick %d, count %d: observe = %d, %t; want extinct %t (since tick 4)", step.tick, step.count, tick, extinct, step.extinct)
+		}
+	}
+}
+
+func TestExtinctionWatchRejectsUnknownSpecies(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	for _, species := range []string{"A", "B"} { // B is only a product so far
+		if _, err := newExtinctionWatch(p, species, 10); err != nil {
+			t.Errorf("newExtinctionWatch(%q): %v", species, err)
+		}
+	}
+	if _, err := newExtinctionWatch(p, "Z", 10); err == nil {
+		t.Error("newExtinctionWatch accepted a species outside the network")
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+// testPond returns a pond with the given counts and reactions and a fixed
+// seed, so tests are reproducible.
+func testPond(seed int64, counts map[string]int, reactions ...Reaction) *Pond {
+	p := NewPondFromCounts(counts, reactions)
+	p.Seed = seed
+	p.rng = newCloneableRand(seed)
+	return p
+}
@@ -0,0 +1,135 @@
+package main
+
+// --- Count History ---
+
+// HistoryCapacity is the default number of points kept by a History.
+const HistoryCapacity = 512
+
+// HistoryPoint summarizes the molecule counts over a span of consecutive ticks.
+type HistoryPoint struct {
+	Tick int            // First tick covered by this point
+	Span int            // Number of ticks merged into this point
+	Last map[string]int // Counts at the last tick of the span
+	Min  map[string]int // Lowest count seen during the span
+	Max  map[string]int // Highest count seen during the span
+}
+
+// History records counts for an entire run in bounded memory. Once it holds
+// Capacity points it merges neighbouring points pairwise and from then on
+// folds twice as many ticks into each new point, so the whole run stays
+// visible at a coarser resolution. Merging keeps the min/max envelope, so
+// spikes are never lost.
+type History struct {
+	Capacity int
+	stride   int // Ticks folded into each new point
+	points   []HistoryPoint
+}
+
+// NewHistory creates an empty history holding at most capacity points (minimum 2).
+func NewHistory(capacity int) *History {
+	if capacity < 2 {
+		capacity = 2
+	}
+	return &History{Capacity: capacity, stride: 1}
+}
+
+// Add records the counts observed at tick.
+func (h *History) Add(tick int, counts map[string]int) {
+	if n := len(h.points); n > 0 && h.points[n-1].Span < h.stride {
+		last := &h.points[n-1]
+		last.Span++
+		for name, count := range counts {
+			last.Last[name] = count
+			if min, ok := last.Min[name]; !ok || count < min {
+				last.Min[name] = count
+			}
+			if count > last.Max[name] {
+				last.Max[name] = count
+			}
+		}
+		return
+	}
+
+	point := HistoryPoint{
+		Tick: tick,
+		Span: 1,
+		Last: make(map[string]int, len(counts)),
+		Min:  make(map[string]int, len(counts)),
+		Max:  make(map[string]int, len(counts)),
+	}
+	for name, count := range counts {
+		point.Last[name] = count
+		point.Min[name] = count
+		point.Max[name] = count
+	}
+	h.points = append(h.points, point)
+
+	if len(h.points) > h.Capacity {
+		h.compact()
+	}
+}
+
+// compact merges neighbouring points pairwise and doubles the stride.
+func (h *History) compact() {
+	merged := h.points[:0]
+	for i := 0; i < len(h.points); i += 2 {
+		if i+1 == len(h.points) {
+			merged = append(merged, h.points[i])
+			break
+		}
+		merged = append(merged, mergePoints(h.points[i], h.points[i+1]))
+	}
+	h.points = merged
+	h.stride *= 2
+}
+
+// mergePoints combines two consecutive points into one covering both spans.
+func mergePoints(a, b HistoryPoint) HistoryPoint {
+	out := HistoryPoint{Tick: a.Tick, Span: a.Span + b.Span, Last: b.Last, Min: a.Min, Max: a.Max}
+	for name, min := range b.Min {
+		if prev, ok := out.Min[name]; !ok || min < prev {
+			out.Min[name] = min
+		}
+	}
+	for name, max := range b.Max {
+		if max > out.Max[name] {
+			out.Max[name] = max
+		}
+	}
+	return out
+}
+
+// Points returns the recorded points, oldest first. The slice must not be modified.
+func (h *History) Points() []HistoryPoint {
+	return h.points
+}
+
+// Len returns the number of points currently stored.
+func (h *History) Len() int {
+	return len(h.points)
+}
+
+// Range returns the lowest and highest count of a species over the whole history.
+func (h *History) Range(species string) (min, max int) {
+	for i, point := range h.points {
+		if i == 0 || point.Min[species] < min {
+			min = point.Min[species]
+		}
+		if point.Max[species] > max {
+			max = point.Max[species]
+		}
+	}
+	return min, max
+}
+
+// HighWater tracks the all-time maximum count of each species.
+type HighWater map[string]int
+
+// Observe raises each species' mark to its current count if that is higher.
+func (h HighWater) Observe(counts map[string]int) {
+	for name, count := range counts {
+		if mark, ok := h[name]; !ok || count > mark {
+			h[name] = count
+		}
+	}
+}
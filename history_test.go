@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestHistoryStaysBoundedAndKeepsExtremes(t *testing.T) {
+	h := NewHistory(16)
+	for tick := 1; tick <= 100000; tick++ {
+		count := tick % 1000
+		switch tick {
+		case 777:
+			count = -5
+		case 50001:
+			count = 99999
+		}
+		h.Add(tick, map[string]int{"E": count})
+		if h.Len() > h.Capacity {
+			t.Fatalf("history holds %d points after tick %d, capacity %d", h.Len(), tick, h.Capacity)
+		}
+	}
+
+	if min, max := h.Range("E"); min != -5 || max != 99999 {
+		t.Errorf("Range(E) = %d, %d; want -5, 99999", min, max)
+	}
+	points := h.Points()
+	if points[0].Tick != 1 {
+		t.Errorf("first point starts at tick %d, want 1", points[0].Tick)
+	}
+	span := 0
+	for _, point := range points {
+		span += point.Span
+	}
+	if span != 100000 {
+		t.Errorf("points cover %d ticks, want 100000", span)
+	}
+	if last := points[len(points)-1].Last["E"]; last != 0 {
+		t.Errorf("last count = %d, want 0", last)
+	}
+}
+
+func TestHighWater(t *testing.T) {
+	series := []int{5, 3, 9, 9, 2, 12, 0, 7}
+	marks := HighWater{}
+	prev, max := 0, 0
+	for i, count := range series {
+		marks.Observe(map[string]int{"E": count})
+		if marks["E"] < prev {
+			t.Fatalf("mark fell from %d to %d at sample %d", prev, marks["E"], i)
+		}
+		prev = marks["E"]
+		if count > max || i == 0 {
+			max = count
+		}
+		if marks["E"] != max {
+			t.Errorf("mark = %d after sample %d, want %d", marks["E"], i, max)
+		}
+	}
+}
+
+func TestHighWaterNegativeFirstCount(t *testing.T) {
+	marks := HighWater{}
+	marks.Observe(map[string]int{"E": -3})
+	if marks["E"] != -3 {
+		t.Errorf("mark = %d, want -3", marks["E"])
+	}
+}
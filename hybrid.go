@@ -0,0 +1,91 @@
+package main
+
+// --- Hybrid Stochastic/Deterministic Mode ---
+
+// fastReactions partitions the network for hybrid mode: a reaction is fast,
+// and integrated deterministically, when it is enabled and every species it
+// consumes or produces has at least ODEThreshold molecules. All other
+// reactions, including any that touch a rare species, fire stochastically.
+// Reactions holding back catalysts or making a capped species are always
+// stochastic.
+func (p *Pond) fastReactions() []bool {
+	fast := make([]bool, len(p.Reactions))
+	for j, r := range p.Reactions {
+		if r.Disabled || r.CatalystDelay > 0 || p.producesCapped(r) {
+			continue
+		}
+		fast[j] = true
+		for _, name := range append(append([]string(nil), r.Reactants...), r.AllProducts()...) {
+			if p.Molecules[name] < p.ODEThreshold {
+				fast[j] = false
+				break
+			}
+		}
+	}
+	return fast
+}
+
+// runHybrid advances the pond by the time n fires would take on average at
+// the current rates, splitting the tick between the two subsystems: the fast
+// reactions are first integrated over the whole interval with RK4, then the
+// slow ones fire one at a time by Gillespie's direct method over the same
+// interval, against the updated abundant counts, each checked as Step checks
+// a fire. The partition is recomputed every tick, so a species moves between
+// the subsystems as it crosses ODEThreshold.
+func (p *Pond) runHybrid(n int) {
+	fast := p.fastReactions()
+	species, pos, x := p.odeState()
+	fixed := p.fixedRates()
+	total := 0.0
+	for j := range p.Reactions {
+		if fast[j] {
+			total += p.odeFlux(j, x, pos, fixed)
+		} else {
+			total += p.Propensity(j)
+		}
+	}
+	p.StepCount += n
+	p.releaseSequestered()
+	if total <= 0 {
+		return
+	}
+	dt := float64(n) / total
+
+	// Deterministic part
+	p.rk4(x, pos, fixed, fast, dt)
+	p.storeAmounts(species, x)
+
+	// Stochastic part; its fires move the abundant amounts by whole units
+	before := make(map[string]int, len(species))
+	for _, name := range species {
+		before[name] = p.Molecules[name]
+	}
+	props := make([]float64, len(p.Reactions))
+	for t := 0.0; ; {
+		slow := 0.0
+		for j := range p.Reactions {
+			props[j] = 0
+			if !fast[j] {
+				props[j] = p.Propensity(j)
+				slow += props[j]
+			}
+		}
+		if slow <= 0 {
+			break
+		}
+		if t += p.rng.ExpFloat64() / slow; t > dt {
+			break
+		}
+		if idx := p.pickWeighted(props); p.canFire(p.Reactions[idx]) && p.succeeds(idx) {
+			p.fire(idx)
+		}
+		if added := len(p.Reactions) - len(props); added > 0 { // Mutants fire stochastically
+			fast = append(fast, make([]bool, added)...)
+			props = append(props, make([]float64, added)...)
+		}
+	}
+	for name, count := range p.Molecules {
+		p.Amounts[name] += float64(count - before[name])
+	}
+	p.Time += dt
+}
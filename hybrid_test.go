@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHybridPartitionsAbundantAndRare(t *testing.T) {
+	const a0 = 1e6
+	p := testPond(1, map[string]int{"A": a0, "B": a0, "S": 10, "R": 10},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"S"}, Product: "R", Rate: 50},
+		Reaction{Reactants: []string{"R"}, Product: "S", Rate: 50},
+	)
+	p.ODEThreshold = 1000
+	p.Hybrid = true
+
+	if fast := p.fastReactions(); !fast[0] || fast[1] || fast[2] {
+		t.Fatalf("fastReactions() = %v, want only A -> B fast", fast)
+	}
+
+	jumps, fractional := 0, false
+	last := p.Molecules["S"]
+	for i := 0; i < 50; i++ {
+		p.Run(10000)
+
+		// The abundant species follows the rate equation
+		want := a0 * math.Exp(-p.Time)
+		if got := p.Amounts["A"]; math.Abs(got-want) > 1e-6*want {
+			t.Fatalf("t=%.4f: A = %.3f, want %.3f", p.Time, got, want)
+		}
+		if p.Amounts["A"] != math.Trunc(p.Amounts["A"]) {
+			fractional = true
+		}
+
+		// The rare ones move by whole molecules
+		s := p.Amounts["S"]
+		if s != math.Trunc(s) || int(s) != p.Molecules["S"] {
+			t.Fatalf("t=%.4f: S amount %v, count %d; want a whole number of molecules", p.Time, s, p.Molecules["S"])
+		}
+		if got := p.Molecules["S"] + p.Molecules["R"]; got != 20 {
+			t.Fatalf("t=%.4f: S + R = %d, want 20", p.Time, got)
+		}
+		if p.Molecules["S"] != last {
+			jumps++
+		}
+		last = p.Molecules["S"]
+	}
+	if !fractional {
+		t.Error("A never held a fractional amount")
+	}
+	if jumps == 0 {
+		t.Error("S never changed, want discrete jumps")
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import "slices"
+
+// --- Reaction Index ---
+
+// reactionIndex records which reactions each species takes part in, so a
+// fire only needs to revisit the reactions it can have affected.
+type reactionIndex struct {
+	size      int              // len(Reactions) the index was built for
+	bySpecies map[string][]int // Species -> reactions consuming it or catalyzed by it
+	affects   [][]int          // Reaction -> reactions whose propensity its firing can change
+	volatile  []int            // Reactions whose propensity can change without their species changing
+	limited   map[string]bool  // Species with a production cap or maximum count, which also limit their producers
+}
+
+// buildReactionIndex indexes the given reactions. A reaction making a limited
+// species also depends on it, as the species' count or production decides
+// whether the reaction may fire.
+func buildReactionIndex(reactions []Reaction, limited map[string]bool) *reactionIndex {
+	idx := &reactionIndex{size: len(reactions), bySpecies: map[string][]int{}, limited: limited}
+	limitedBy := map[string][]int{} // Limited species -> reactions making it
+	for i, r := range reactions {
+		seen := map[string]bool{}
+		for _, name := range append(append([]string(nil), r.Reactants...), r.AllCatalysts()...) {
+			if !seen[name] {
+				seen[name] = true
+				idx.bySpecies[name] = append(idx.bySpecies[name], i)
+			}
+		}
+		// Rate laws may read anything; density, neighbour catalysis, product
+		// inhibition and reactant ratios depend on other species or cells
+		if r.RateLaw != nil || r.MinTotalPopulation > 0 || r.NeighborCatalyzed || r.ProductInhibition > 0 || r.MinReactantRatio != nil {
+			idx.volatile = append(idx.volatile, i)
+		}
+		for _, name := range r.AllProducts() {
+			if limited[name] && !slices.Contains(limitedBy[name], i) {
+				limitedBy[name] = append(limitedBy[name], i)
+			}
+		}
+	}
+
+	idx.affects = make([][]int, len(reactions))
+	for i, r := range reactions {
+		changed := append(append([]string(nil), r.Reactants...), r.AllProducts()...)
+		if r.CatalystDelay > 0 && !r.NeighborCatalyzed {
+			changed = append(changed, r.AllCatalysts()...)
+		}
+		seen := map[int]bool{}
+		for _, name := range changed {
+			for _, j := range slices.Concat(idx.bySpecies[name], limitedBy[name]) {
+				if !seen[j] {
+					seen[j] = true
+					idx.affects[i] = append(idx.affects[i], j)
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// reactionIndex returns the index for the current reactions, rebuilding it
+// when reactions have been added or removed or the limited species changed.
+func (p *Pond) reactionIndex() *reactionIndex {
+	if p.index == nil || p.index.size != len(p.Reactions) || !p.index.sameLimits(p) {
+		p.index = buildReactionIndex(p.Reactions, p.limitedSpecies())
+	}
+	return p.index
+}
+
+// sameLimits reports whether the index was built for the pond's current
+// production caps and maximum counts.
+func (idx *reactionIndex) sameLimits(p *Pond) bool {
+	n := len(p.ProductionCaps)
+	for species := range p.ProductionCaps {
+		if !idx.limited[species] {
+			return false
+		}
+	}
+	for species := range p.MaxCount {
+		if !idx.limited[species] {
+			return false
+		}
+		if _, ok := p.ProductionCaps[species]; !ok {
+			n++
+		}
+	}
+	return len(idx.limited) == n
+}
+
+// limitedSpecies returns the species with a production cap or a maximum count.
+func (p *Pond) limitedSpecies() map[string]bool {
+	limited := map[string]bool{}
+	for species := range p.ProductionCaps {
+		limited[species] = true
+	}
+	for species := range p.MaxCount {
+		limited[species] = true
+	}
+	return limited
+}
+
+// ReactionsUsing returns the indices of the reactions that consume species
+// or are catalyzed by it.
+func (p *Pond) ReactionsUsing(species string) []int {
+	return append([]int(nil), p.reactionIndex().bySpecies[species]...)
+}
+
+// AddReaction appends a reaction, with its pools resolved, to the network
+// and returns its index.
+func (p *Pond) AddReaction(r Reaction) int {
+	p.Reactions = append(p.Reactions, r.Pooled())
+	p.seedReactionRands()
+	p.index = nil
+	return len(p.Reactions) - 1
+}
+
+// RemoveReaction deletes reaction i; later reactions move down one index.
+func (p *Pond) RemoveReaction(i int) {
+	p.seedReactionRands() // The remaining reactions keep their streams
+	p.Reactions = append(p.Reactions[:i:i], p.Reactions[i+1:]...)
+	if i < len(p.FireCounts) {
+		p.FireCounts = append(p.FireCounts[:i:i], p.FireCounts[i+1:]...)
+	}
+	p.reactionRands = append(p.reactionRands[:i:i], p.reactionRands[i+1:]...)
+	p.index = nil
+}
+
+// Run performs n steps. In Gillespie mode the propensities are computed once
+// and, after each fire, only those of the reactions it affected are
+// recomputed, which is much faster on large networks than recomputing all
+// of them every step. Molecules and Reactions must not be changed by other
+// code while Run is in progress.
+//
+// In ODE mode, while every reactant is abundant, Run instead integrates the
+// rate equations over the time n fires would take; in hybrid mode it does
+// so for the abundant species only. With Batch set, runs of repeated
+// selections of one reaction are applied together (see runBatched).
+func (p *Pond) Run(n int) {
+	clear(p.produced) // A new tick: production caps start over
+	if p.Hybrid && p.ODEThreshold > 0 && len(p.Reactions) > 0 {
+		p.runHybrid(n)
+		return
+	}
+	if p.odeEligible() {
+		p.integrateODE(n)
+		return
+	}
+	p.Amounts = nil
+	if p.batchable() {
+		p.runBatched(n)
+		return
+	}
+	if p.Gillespie && len(p.Reactions) > 0 {
+		// Recomputed every tick, as capped reactions can fire again
+		p.weights = p.fillPropensities(p.weights)
+		p.propsCached = true
+		defer func() { p.propsCached = false }()
+	}
+	for i := 0; i < n; i++ {
+		p.Step()
+	}
+}
+
+// refreshPropensities recomputes the cached propensities of the given reactions.
+func (p *Pond) refreshPropensities(reactions []int) {
+	for _, j := range reactions {
+		p.weights[j] = p.Propensity(j)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// usingByScan finds the reactions consuming or catalyzed by species the slow way.
+func usingByScan(p *Pond, species string) []int {
+	var using []int
+	for i, r := range p.Reactions {
+		if slices.Contains(r.Reactants, species) || slices.Contains(r.AllCatalysts(), species) {
+			using = append(using, i)
+		}
+	}
+	return using
+}
+
+func checkIndex(t *testing.T, p *Pond, when string) {
+	t.Helper()
+	for _, name := range p.networkSpecies() {
+		got, want := p.ReactionsUsing(name), usingByScan(p, name)
+		if len(got) != len(want) || (len(got) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("%s: ReactionsUsing(%s) = %v, want %v", when, name, got, want)
+		}
+	}
+}
+
+func TestReactionIndexAfterAddAndRemove(t *testing.T) {
+	p := NewPondWithSeed(1)
+	checkIndex(t, p, "initially")
+
+	p.AddReaction(Reaction{Reactants: []string{"E", "B"}, Product: "F", Catalysts: []string{"D"}})
+	checkIndex(t, p, "after adding")
+	if got := p.ReactionsUsing("F"); len(got) != 0 {
+		t.Errorf("product F indexed as used by %v", got)
+	}
+
+	p.RemoveReaction(0)
+	checkIndex(t, p, "after removing R1")
+	p.RemoveReaction(len(p.Reactions) - 1)
+	checkIndex(t, p, "after removing the added reaction")
+
+	p.Run(100) // The index is used while stepping
+	p.AddReaction(Reaction{Reactants: []string{"C"}, Product: "A"})
+	checkIndex(t, p, "after adding mid-run")
+}
+
+func TestCachedPropensitiesMatchFresh(t *testing.T) {
+	networks := map[string]*Pond{"default": NewPondWithSeed(1)}
+	delayed := NewPondWithSeed(1)
+	delayed.Reactions[2].CatalystDelay = 5 // Releases refresh the cache too
+	networks["delayed catalyst"] = delayed
+	networks["random"] = randomTestNetwork(t, 64)
+
+	for name, p := range networks {
+		t.Run(name, func(t *testing.T) {
+			// Run's Gillespie loop, checking the cache after every step
+			p.Gillespie = true
+			p.weights = p.fillPropensities(p.weights)
+			p.propsCached = true
+			for i := 0; i < 5000; i++ {
+				p.Step()
+				if fresh := p.Propensities(); !slices.Equal(p.weights, fresh) {
+					t.Fatalf("step %d: cached propensities %v, fresh %v", i, p.weights, fresh)
+				}
+			}
+		})
+	}
+}
+
+// randomTestNetwork returns a pond of n random reactions over 10 species.
+func randomTestNetwork(t testing.TB, n int) *Pond {
+	molecules := make([]Molecule, 10)
+	counts := make(map[string]int, len(molecules))
+	for i := range molecules {
+		molecules[i].Name = fmt.Sprintf("S%d", i)
+		counts[molecules[i].Name] = 50
+	}
+	reactions, err := GenerateRandomReactions(molecules, n, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testPond(1, counts, reactions...)
+}
+
+// BenchmarkGillespieIndexed runs Gillespie steps refreshing only the
+// propensities a fire affected; compare BenchmarkGillespieRecompute.
+func BenchmarkGillespieIndexed(b *testing.B) {
+	p := randomNetwork(b, 1024)
+	p.Gillespie = true
+	b.ReportAllocs()
+	b.ResetTimer()
+	p.Run(b.N)
+}
+
+// BenchmarkGillespieRecompute runs Gillespie steps recomputing every propensity each step.
+func BenchmarkGillespieRecompute(b *testing.B) {
+	p := randomNetwork(b, 1024)
+	p.Gillespie = true
+	benchmarkSteps(b, p)
+}
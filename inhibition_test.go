@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+// inhibitedShare runs n steps and returns the share of fires that went to reaction 0.
+func inhibitedShare(p *Pond, n int) float64 {
+	before := p.FireCounts[0] + p.FireCounts[1]
+	first := p.FireCounts[0]
+	p.Run(n)
+	return float64(p.FireCounts[0]-first) / float64(p.FireCounts[0]+p.FireCounts[1]-before)
+}
+
+func TestProductInhibitionSlowsAndRecovers(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 1000000, "P": 0, "Q": 0},
+		Reaction{Reactants: []string{"A"}, Product: "P", ProductInhibition: 0.01},
+		Reaction{Reactants: []string{"A"}, Product: "Q"}, // Uninhibited reference
+	)
+	p.Gillespie = true
+	p.FireCounts = make([]int, 2)
+
+	fresh := inhibitedShare(p, 200)
+	p.Run(20000)
+	inhibited := inhibitedShare(p, 2000)
+	p.Molecules["P"] = 0
+	recovered := inhibitedShare(p, 200)
+
+	if inhibited > fresh/3 {
+		t.Errorf("share of fires %.3f with P = %d, want well below %.3f without it", inhibited, p.Molecules["P"], fresh)
+	}
+	if recovered < 2*inhibited {
+		t.Errorf("share of fires %.3f after removing P, want it back near %.3f", recovered, fresh)
+	}
+}
+
+func TestInhibitedRate(t *testing.T) {
+	r := Reaction{Reactants: []string{"A"}, Product: "P", Rate: 2, ProductInhibition: 0.5}
+	p := testPond(1, map[string]int{"A": 1, "P": 6}, r)
+	if got := p.InhibitedRate(r); got != 0.5 {
+		t.Errorf("InhibitedRate() = %v with 6 P, want 2 / (1 + 0.5*6) = 0.5", got)
+	}
+	r.ProductInhibition = 0
+	if got := p.InhibitedRate(r); got != 2 {
+		t.Errorf("InhibitedRate() = %v without inhibition, want 2", got)
+	}
+}
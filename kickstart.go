@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- Autocatalysis Kick-start ---
+
+// Kickstart is the molecules a pond is seeded with to bootstrap
+// autocatalysis, written "species:amount" (e.g. "E:1"). An amount of zero
+// leaves the catalyst to arise spontaneously through the uncatalyzed
+// reactions.
+type Kickstart struct {
+	Species string
+	Amount  int
+}
+
+// DefaultKickstart is the single E that the default chemistry starts with.
+var DefaultKickstart = Kickstart{Species: "E", Amount: 1}
+
+// Apply sets the kick-start species' count in molecules.
+func (k Kickstart) Apply(molecules map[string]int) {
+	if k.Species != "" {
+		molecules[k.Species] = k.Amount
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler; the zero Kickstart is "".
+func (k Kickstart) MarshalText() ([]byte, error) {
+	if k.Species == "" {
+		return nil, nil
+	}
+	return []byte(k.Species + ":" + strconv.Itoa(k.Amount)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing "species:amount".
+func (k *Kickstart) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*k = Kickstart{}
+		return nil
+	}
+	name, amount, ok := strings.Cut(string(text), ":")
+	n, err := strconv.Atoi(amount)
+	if !ok || err != nil || n < 0 || !isSpeciesName(name) {
+		return fmt.Errorf("invalid kickstart %q (want species:amount)", text)
+	}
+	*k = Kickstart{Species: name, Amount: n}
+	return nil
+}
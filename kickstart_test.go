@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestZeroKickstartNucleatesThroughR2(t *testing.T) {
+	p := NewPondWithKickstart(1, Kickstart{Species: "E", Amount: 0})
+	if p.Molecules["E"] != 0 {
+		t.Fatalf("E = %d at tick 0, want 0", p.Molecules["E"])
+	}
+	for i := 0; i < 100000 && p.Molecules["E"] == 0; i++ {
+		p.Step()
+	}
+	if p.Molecules["E"] == 0 {
+		t.Fatal("E never arose")
+	}
+	// Without a catalyst the autocatalytic reaction cannot have fired
+	if p.FireCounts[1] != 1 || p.FireCounts[2] != 0 {
+		t.Errorf("first E after %d R2 fires and %d R3 fires, want 1 and 0", p.FireCounts[1], p.FireCounts[2])
+	}
+}
+
+func TestKickstartSeedsPond(t *testing.T) {
+	p := NewPondWithKickstart(1, Kickstart{Species: "E", Amount: 7})
+	if p.Molecules["E"] != 7 {
+		t.Errorf("E = %d at tick 0, want 7", p.Molecules["E"])
+	}
+	if got := NewPondWithSeed(1).Molecules["E"]; got != DefaultKickstart.Amount {
+		t.Errorf("default pond has E = %d, want %d", got, DefaultKickstart.Amount)
+	}
+}
+
+func TestKickstartText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Kickstart
+		ok   bool
+	}{
+		{"E:1", Kickstart{"E", 1}, true},
+		{"D:0", Kickstart{"D", 0}, true},
+		{"X_2:40", Kickstart{"X_2", 40}, true},
+		{"", Kickstart{}, true},
+		{"E", Kickstart{}, false},
+		{"E:-1", Kickstart{}, false},
+		{"E:many", Kickstart{}, false},
+		{"1E:3", Kickstart{}, false},
+	}
+	for _, tt := range tests {
+		var k Kickstart
+		err := k.UnmarshalText([]byte(tt.in))
+		if (err == nil) != tt.ok || (tt.ok && k != tt.want) {
+			t.Errorf("UnmarshalText(%q) = %+v, %v; want %+v, ok %t", tt.in, k, err, tt.want, tt.ok)
+			continue
+		}
+		if tt.ok {
+			if text, _ := k.MarshalText(); string(text) != tt.in {
+				t.Errorf("MarshalText(%+v) = %q, want %q", k, text, tt.in)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestLastReactionAfterSteps(t *testing.T) {
+	p := NewPondWithSeed(1)
+	if got := p.LastReaction(); got != message(MsgInitialized) {
+		t.Errorf("LastReaction() = %q before any step, want %q", got, message(MsgInitialized))
+	}
+
+	descriptions := []string{
+		"Reaction: A + B -> D",
+		"Reaction: D + C -> E",
+		"Reaction: D + A -> E (Cat: E)",
+		"Reaction: E -> A",
+	}
+	last := -1
+	p.AddFireObserver(func(reaction, branch int) { last = reaction })
+	for batch := 0; batch < 20; batch++ {
+		p.Run(137)
+		if last < 0 {
+			continue
+		}
+		if got := p.LastReaction(); got != descriptions[last] {
+			t.Fatalf("batch %d: LastReaction() = %q, want %q", batch, got, descriptions[last])
+		}
+	}
+	if last < 0 {
+		t.Fatal("nothing fired")
+	}
+}
+
+func TestLastReactionShowsStatus(t *testing.T) {
+	p := NewPondWithSeed(1)
+	p.Run(100)
+	p.Status = "Config saved"
+	if got := p.LastReaction(); got != "Config saved" {
+		t.Errorf("LastReaction() = %q, want the status message", got)
+	}
+}
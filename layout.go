@@ -0,0 +1,30 @@
+package main
+
+import "image"
+
+// --- Screen Layout ---
+
+// screenLayout holds the screen regions used by Draw.
+type screenLayout struct {
+	Table image.Rectangle // Molecule table; empty in compact HUD mode
+	Graph image.Rectangle // Live time-series graph
+	Strip image.Rectangle // Abundance heat strip along the bottom edge
+}
+
+// computeLayout splits the screen between the molecule table and the graph.
+// In compact HUD mode the table is hidden and the graph takes over its space,
+// leaving only a status line at the top and room for messages at the bottom.
+func computeLayout(compact bool, width, height int) screenLayout {
+	const margin = 20
+	if compact {
+		return screenLayout{
+			Graph: image.Rect(margin, 50, width-margin, height-50),
+			Strip: image.Rect(margin, height-14, width-margin, height-4),
+		}
+	}
+	return screenLayout{
+		Table: image.Rect(margin-12, tableHeaderY-15, width-margin, 370),
+		Graph: image.Rect(margin, 380, width-margin, 530),
+		Strip: image.Rect(margin, height-14, width-margin, height-4),
+	}
+}
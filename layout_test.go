@@ -0,0 +1,33 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestComputeLayout(t *testing.T) {
+	full := computeLayout(false, 800, 600)
+	compact := computeLayout(true, 800, 600)
+
+	if full.Table.Empty() {
+		t.Error("full layout has no table")
+	}
+	if !compact.Table.Empty() {
+		t.Errorf("compact layout shows the table at %v", compact.Table)
+	}
+	if compact.Graph.Dy() <= full.Graph.Dy() {
+		t.Errorf("compact graph height %d is not larger than full %d", compact.Graph.Dy(), full.Graph.Dy())
+	}
+
+	screen := image.Rect(0, 0, 800, 600)
+	for name, layout := range map[string]screenLayout{"full": full, "compact": compact} {
+		for _, region := range []image.Rectangle{layout.Table, layout.Graph, layout.Strip} {
+			if !region.Empty() && !region.In(screen) {
+				t.Errorf("%s layout region %v is off screen", name, region)
+			}
+		}
+		if layout.Graph.Overlaps(layout.Strip) || layout.Table.Overlaps(layout.Graph) {
+			t.Errorf("%s layout regions overlap: %+v", name, layout)
+		}
+	}
+}
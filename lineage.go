@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// LineageInitial is the pseudo reaction index used for units present before
+// the first step or added from outside (e.g. by injection).
+const LineageInitial = -1
+
+// EnableLineage turns on provenance tracking. Every unit currently in the pond
+// is attributed to LineageInitial; from then on Step records which reaction
+// produced each unit and removes consumed units from the histogram.
+func (p *Pond) EnableLineage() {
+	p.Lineage = make(map[string]map[int]int)
+	for name, count := range p.Molecules {
+		p.Lineage[name] = map[int]int{}
+		if count > 0 {
+			p.Lineage[name][LineageInitial] = count
+		}
+	}
+	// A private source, so tracking doesn't perturb the simulation's own random stream
+	p.lineageRand = rand.New(rand.NewSource(1))
+}
+
+// recordProduced attributes a newly produced unit of species to reaction index idx.
+func (p *Pond) recordProduced(species string, idx int) {
+	if p.Lineage[species] == nil {
+		p.Lineage[species] = map[int]int{}
+	}
+	p.Lineage[species][idx]++
+}
+
+// recordConsumed removes one unit of species from its histogram, picking the
+// source at random in proportion to how many units each source contributed.
+func (p *Pond) recordConsumed(species string) {
+	hist := p.Lineage[species]
+	total := 0
+	for _, n := range hist {
+		total += n
+	}
+	if total == 0 {
+		return
+	}
+
+	pick := p.lineageRand.Intn(total)
+	for _, idx := range sortedSources(hist) {
+		pick -= hist[idx]
+		if pick < 0 {
+			hist[idx]--
+			if hist[idx] == 0 {
+				delete(hist, idx)
+			}
+			return
+		}
+	}
+}
+
+// sortedSources returns the reaction indices of a histogram in ascending order.
+func sortedSources(hist map[int]int) []int {
+	sources := make([]int, 0, len(hist))
+	for idx := range hist {
+		sources = append(sources, idx)
+	}
+	sort.Ints(sources)
+	return sources
+}
+
+// LineageSummary formats the production-source breakdown for a species, e.g. "Initial: 1, R3: 42".
+func (p *Pond) LineageSummary(species string) string {
+	hist := p.Lineage[species]
+	if len(hist) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(hist))
+	for _, idx := range sortedSources(hist) {
+		label := "Initial"
+		if idx != LineageInitial {
+			label = fmt.Sprintf("R%d", idx+1)
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", label, hist[idx]))
+	}
+	return strings.Join(parts, ", ")
+}
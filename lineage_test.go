@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineageMatchesFires(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10, "B": 2, "C": 10},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"C"}, Product: "B"},
+	)
+	p.EnableLineage()
+	for _, idx := range []int{0, 1, 0, 0, 1} {
+		p.fire(idx)
+	}
+
+	want := map[int]int{LineageInitial: 2, 0: 3, 1: 2}
+	if !reflect.DeepEqual(p.Lineage["B"], want) {
+		t.Errorf("lineage of B = %v, want %v", p.Lineage["B"], want)
+	}
+	if want := map[int]int{LineageInitial: 7}; !reflect.DeepEqual(p.Lineage["A"], want) {
+		t.Errorf("lineage of A = %v, want %v", p.Lineage["A"], want)
+	}
+}
+
+func TestLineageTotalsMatchCounts(t *testing.T) {
+	p := NewPondWithSeed(1)
+	p.EnableLineage()
+	for i := 0; i < 20000; i++ {
+		p.Step()
+	}
+	for name, count := range p.Molecules {
+		total := 0
+		for _, n := range p.Lineage[name] {
+			total += n
+		}
+		if total != count {
+			t.Errorf("lineage of %s totals %d, count is %d", name, total, count)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+)
+
+// --- Logging ---
+
+// LogLevel orders log messages by importance; a message is printed when its
+// level is at or below the current verbosity.
+type LogLevel int
+
+const (
+	LevelError LogLevel = iota // Failures, e.g. a checkpoint that could not be written
+	LevelWarn                  // Suspicious but recoverable situations
+	LevelInfo                  // Notable simulation events: emergence, extinctions
+	LevelDebug                 // Every fired reaction
+)
+
+var logLevelNames = [...]string{"error", "warn", "info", "debug"}
+
+func (l LogLevel) String() string {
+	if l < 0 || int(l) >= len(logLevelNames) {
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+	return logLevelNames[l]
+}
+
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	for i, name := range logLevelNames {
+		if string(text) == name {
+			*l = LogLevel(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log level %q (want error, warn, info or debug)", text)
+}
+
+// logLevel is the current verbosity, set by the -v flag.
+var logLevel = LevelInfo
+
+// shouldLog reports whether a message at level is printed at verbosity current.
+func shouldLog(current, level LogLevel) bool {
+	return level <= current
+}
+
+// logf prints a message to stderr, tagged with its level, if the current
+// verbosity allows it.
+func logf(level LogLevel, format string, args ...any) {
+	if !shouldLog(logLevel, level) {
+		return
+	}
+	log.Printf("["+level.String()+"] "+format, args...)
+}
+
+// tickAlerts returns an observer that reports, at info level, the first time
+// emergence is seen and every species whose count fell to zero since the
+// previous tick.
+func (g *Game) tickAlerts() func(tick int, p *Pond) {
+	var emergence emergenceTrigger
+	prev := maps.Clone(g.Pond.Molecules)
+	return func(tick int, p *Pond) {
+		if !shouldLog(logLevel, LevelInfo) {
+			return // Nothing would be printed; skip the per-tick copy of the counts
+		}
+		if emergence.Observe(g.Emerged()) {
+			logf(LevelInfo, "tick %d: emergence reached (E: %d)", tick, p.Molecules["E"])
+		}
+		for _, name := range p.SpeciesNames() {
+			if prev[name] > 0 && p.Molecules[name] == 0 {
+				logf(LevelInfo, "tick %d: %s went extinct", tick, name)
+			}
+		}
+		prev = maps.Clone(p.Molecules)
+	}
+}
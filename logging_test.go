@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestShouldLog(t *testing.T) {
+	tests := []struct {
+		current, level LogLevel
+		want           bool
+	}{
+		{LevelInfo, LevelDebug, false},
+		{LevelInfo, LevelInfo, true},
+		{LevelInfo, LevelWarn, true},
+		{LevelInfo, LevelError, true},
+		{LevelError, LevelWarn, false},
+		{LevelError, LevelError, true},
+		{LevelDebug, LevelDebug, true},
+	}
+	for _, tt := range tests {
+		if got := shouldLog(tt.current, tt.level); got != tt.want {
+			t.Errorf("shouldLog(%v, %v) = %t, want %t", tt.current, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestLogLevelText(t *testing.T) {
+	var level LogLevel
+	if err := level.UnmarshalText([]byte("debug")); err != nil || level != LevelDebug {
+		t.Errorf("UnmarshalText(debug) = %v, %v", level, err)
+	}
+	if err := level.UnmarshalText([]byte("verbose")); err == nil {
+		t.Error("UnmarshalText(verbose) succeeded, want an error")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// autocatalyticPond returns a pond in which E copies itself from plentiful
+// food, with E's count capped at 1000, and E also catalyzes the uncapped W.
+func autocatalyticPond() *Pond {
+	p := testPond(1, map[string]int{"A": 1e6, "E": 10, "W": 0},
+		Reaction{Reactants: []string{"A"}, Product: "E", Catalysts: []string{"E"}, Rate: 10},
+		Reaction{Reactants: []string{"A"}, Product: "W", Catalysts: []string{"E"}, Rate: 10},
+	)
+	p.MaxCount = map[string]int{"E": 1000}
+	return p
+}
+
+func TestMaxCountHoldsUnderAutocatalysis(t *testing.T) {
+	for _, mode := range []string{"step", "gillespie", "ode", "hybrid"} {
+		t.Run(mode, func(t *testing.T) {
+			p := autocatalyticPond()
+			switch mode {
+			case "gillespie":
+				p.Gillespie = true
+			case "ode":
+				p.ODEThreshold = 5
+			case "hybrid":
+				p.ODEThreshold = 5
+				p.Hybrid = true
+			}
+			for tick := 0; tick < 100; tick++ {
+				p.Run(100)
+				if got := p.Molecules["E"]; got > 1000 {
+					t.Fatalf("tick %d: E = %d, above its maximum of 1000", tick, got)
+				}
+			}
+			if got := p.Molecules["E"]; got != 1000 {
+				t.Errorf("E = %d after 100 ticks, want it held at 1000", got)
+			}
+			if got := p.Molecules["W"]; got <= 1000 {
+				t.Errorf("uncapped W = %d, want it to keep growing past 1000", got)
+			}
+		})
+	}
+}
+
+func TestMaxCountLeavesOtherSpeciesUncapped(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 5000, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"})
+	p.MaxCount = map[string]int{"E": 1000}
+	p.Run(5000)
+	if p.Molecules["B"] != 5000 {
+		t.Errorf("B = %d, want all 5000 A converted", p.Molecules["B"])
+	}
+}
+
+func TestBelowMax(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 10, "B": 999})
+	p.MaxCount = map[string]int{"B": 1000}
+	tests := []struct {
+		name string
+		r    Reaction
+		want bool
+	}{
+		{"one unit to the maximum", Reaction{Reactants: []string{"A"}, Product: "B"}, true},
+		{"yield passes the maximum", Reaction{Reactants: []string{"A"}, Product: "B", ProductYield: 2}, false},
+		{"no net gain", Reaction{Reactants: []string{"B", "A"}, Product: "B", ByProducts: []string{"B"}}, true},
+		{"uncapped product", Reaction{Reactants: []string{"B"}, Product: "A", ByProducts: []string{"A"}}, true},
+		{"branch could pass it", Reaction{Reactants: []string{"A"}, Branches: []Branch{{Products: []string{"B", "B"}}}}, false},
+	}
+	for _, tt := range tests {
+		if got := p.belowMax(tt.r); got != tt.want {
+			t.Errorf("%s: belowMax = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGillespieSpendsStepsBelowMax(t *testing.T) {
+	// A -> E and D -> E stop at E's maximum, and E -> F frees room again
+	p := testPond(1, map[string]int{"A": 1e6, "D": 1e6, "E": 0},
+		Reaction{Reactants: []string{"A"}, Product: "E"},
+		Reaction{Reactants: []string{"D"}, Product: "E"},
+		Reaction{Reactants: []string{"A"}, Product: "C"},
+		Reaction{Reactants: []string{"E"}, Product: "F", Rate: 1e-3},
+	)
+	p.MaxCount = map[string]int{"E": 20}
+	p.Gillespie = true
+	for tick := 1; tick <= 20; tick++ {
+		p.Run(100)
+		if got := p.Molecules["E"]; got > 20 {
+			t.Fatalf("tick %d: E = %d, above its maximum of 20", tick, got)
+		}
+		if got, want := p.TotalFires(), 100*tick; got != want {
+			t.Fatalf("tick %d: %d fires, want one every step (%d)", tick, got, want)
+		}
+	}
+	if affected := p.reactionIndex().affects[3]; !slices.Contains(affected, 0) || !slices.Contains(affected, 1) {
+		t.Errorf("E -> F affects %v, want the reactions making E (0 and 1)", affected)
+	}
+}
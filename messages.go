@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// --- UI Messages ---
+
+// Keys of the user-facing messages that can be reworded or translated.
+const (
+	MsgInitialized = "initialized" // Status of a freshly built pond
+	MsgNoReactions = "noReactions" // Status when a pond has nothing to fire
+	MsgEmergence   = "emergence"   // Emergence banner; formatted with the E count
+)
+
+// DefaultMessages are the built-in English messages.
+var DefaultMessages = map[string]string{
+	MsgInitialized: "Simulation Initialized",
+	MsgNoReactions: "No reactions defined.",
+	MsgEmergence:   "!!! CAS DOMINANCE ACHIEVED (E: %d) !!!",
+}
+
+// messageOverrides replaces individual default messages; see SetMessages.
+var messageOverrides map[string]string
+
+// SetMessages replaces the messages named in overrides, for localization or
+// custom wording. Keys it leaves out keep their default text; nil restores
+// all defaults. Ponds built earlier keep the status they were created with.
+func SetMessages(overrides map[string]string) {
+	messageOverrides = overrides
+}
+
+// message returns the text for key, formatted with args when given.
+func message(key string, args ...any) string {
+	text, ok := messageOverrides[key]
+	if !ok {
+		text = DefaultMessages[key]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// LoadMessages reads message overrides from a JSON object such as
+// {"initialized": "Simulation initialisée"}. Unknown keys are rejected so a
+// typo does not silently leave the default in place.
+func LoadMessages(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading messages: %w", err)
+	}
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing messages %s: %w", path, err)
+	}
+	for key := range overrides {
+		if _, ok := DefaultMessages[key]; !ok {
+			return nil, fmt.Errorf("messages %s: unknown key %q", path, key)
+		}
+	}
+	return overrides, nil
+}
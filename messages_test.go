@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetMessagesOverridesAndFallsBack(t *testing.T) {
+	t.Cleanup(func() { SetMessages(nil) })
+	SetMessages(map[string]string{
+		MsgInitialized: "Simulation initialisée",
+		MsgEmergence:   "Dominance atteinte (E : %d)",
+	})
+
+	if got := NewPond().Status; got != "Simulation initialisée" {
+		t.Errorf("new pond status %q, want the override", got)
+	}
+	if got, want := message(MsgEmergence, 5000), "Dominance atteinte (E : 5000)"; got != want {
+		t.Errorf("emergence message %q, want %q", got, want)
+	}
+	if got, want := message(MsgNoReactions), DefaultMessages[MsgNoReactions]; got != want {
+		t.Errorf("unset key gave %q, want the default %q", got, want)
+	}
+
+	SetMessages(nil)
+	if got, want := NewPond().Status, DefaultMessages[MsgInitialized]; got != want {
+		t.Errorf("status %q after restoring defaults, want %q", got, want)
+	}
+}
+
+func TestLoadMessages(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "fr.json")
+	if err := os.WriteFile(good, []byte(`{"noReactions": "Aucune réaction."}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := LoadMessages(good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overrides) != 1 || overrides[MsgNoReactions] != "Aucune réaction." {
+		t.Errorf("LoadMessages() = %v", overrides)
+	}
+
+	typo := filepath.Join(dir, "typo.json")
+	if err := os.WriteFile(typo, []byte(`{"initialised": "x"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMessages(typo); err == nil {
+		t.Error("LoadMessages accepted an unknown key")
+	}
+	if _, err := LoadMessages(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadMessages accepted a missing file")
+	}
+}
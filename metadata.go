@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// --- Run Metadata ---
+
+// RunMetadata describes the run a log file came from, so results stay
+// self-describing after the command line is forgotten.
+type RunMetadata struct {
+	Seed         int64     `json:"seed"`
+	ConfigPath   string    `json:"configPath,omitempty"` // Empty for the built-in chemistry
+	ConfigHash   string    `json:"configHash"`           // SHA-256 of the effective config as saved by SaveConfig
+	StepsPerTick int       `json:"stepsPerTick"`
+	StartTime    time.Time `json:"startTime"`
+	Version      string    `json:"version"` // Module version of the binary, "(devel)" for local builds
+}
+
+// NewRunMetadata records the game's current parameters, started at start.
+func NewRunMetadata(g *Game, configPath string, start time.Time) (RunMetadata, error) {
+	data, err := json.Marshal(g.Config())
+	if err != nil {
+		return RunMetadata{}, err
+	}
+	sum := sha256.Sum256(data)
+	return RunMetadata{
+		Seed:         g.Pond.Seed,
+		ConfigPath:   configPath,
+		ConfigHash:   hex.EncodeToString(sum[:]),
+		StepsPerTick: g.StepsPerTick,
+		StartTime:    start,
+		Version:      buildVersion(),
+	}, nil
+}
+
+// buildVersion returns the main module's version from the build info.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// metadataPath returns the sidecar path for a log file: its extension
+// replaced by ".meta.json", e.g. events.jsonl -> events.meta.json.
+func metadataPath(logPath string) string {
+	return strings.TrimSuffix(logPath, filepath.Ext(logPath)) + ".meta.json"
+}
+
+// WriteRunMetadata writes m as indented JSON to the sidecar of logPath.
+func WriteRunMetadata(logPath string, m RunMetadata) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metadataPath(logPath), append(data, '\n'), 0o644)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMetadataPath(t *testing.T) {
+	tests := map[string]string{
+		"events.jsonl":     "events.meta.json",
+		"out/run.log":      "out/run.meta.json",
+		"noext":            "noext.meta.json",
+		"a.b/events.jsonl": "a.b/events.meta.json",
+	}
+	for log, want := range tests {
+		if got := metadataPath(log); got != want {
+			t.Errorf("metadataPath(%q) = %q, want %q", log, got, want)
+		}
+	}
+}
+
+func TestEventLogWritesMetadataSidecar(t *testing.T) {
+	dir := t.TempDir()
+	g := NewGame()
+	g.Pond.Seed = 42
+	g.StepsPerTick = 37
+	configPath := filepath.Join(dir, "run.json")
+	if err := g.SaveConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+	eventsPath := filepath.Join(dir, "events.jsonl")
+	if err := headlessCommand([]string{"-config", configPath, "-events", eventsPath, "-steps", "100", "-quiet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "events.meta.json"))
+	if err != nil {
+		t.Fatalf("no metadata sidecar: %v", err)
+	}
+	var meta RunMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Seed != 42 || meta.StepsPerTick != 37 || meta.ConfigPath != configPath {
+		t.Errorf("sidecar records seed %d, steps per tick %d, config %q; want 42, 37, %q",
+			meta.Seed, meta.StepsPerTick, meta.ConfigPath, configPath)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewRunMetadata(cfg.NewGame(), configPath, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.ConfigHash != want.ConfigHash || meta.Version != want.Version || meta.StartTime.IsZero() {
+		t.Errorf("sidecar hash %s version %q start %v, want hash %s version %q", meta.ConfigHash, meta.Version, meta.StartTime, want.ConfigHash, want.Version)
+	}
+}
+
+func TestRunMetadataCanBeDisabled(t *testing.T) {
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, "events.jsonl")
+	if err := headlessCommand([]string{"-events", eventsPath, "-steps", "100", "-quiet", "-run-metadata=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "events.meta.json")); !os.IsNotExist(err) {
+		t.Errorf("sidecar written with -run-metadata=false (stat error %v)", err)
+	}
+}
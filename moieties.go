@@ -0,0 +1,136 @@
+package main
+
+import (
+	"math/big"
+	"sort"
+)
+
+// --- Conserved Moieties ---
+
+// ConservationLaws returns a basis of the conserved quantities of the
+// network: integer weights per species whose weighted sum of counts no
+// reaction outcome changes (the left null space of the stoichiometric
+// matrix). Catalyst units held back by a reaction still count towards the
+// sum. Each law is scaled to the smallest integers with a positive first
+// weight; species with weight zero are omitted.
+func (p *Pond) ConservationLaws() []map[string]int {
+	matrix, species, outcomes := p.StoichiometryMatrix()
+
+	// Transposed: one row per reaction outcome, one column per species
+	rows := make([][]*big.Rat, len(outcomes))
+	for i := range outcomes {
+		rows[i] = make([]*big.Rat, len(species))
+		for j := range species {
+			rows[i][j] = big.NewRat(int64(matrix[j][i]), 1)
+		}
+	}
+	pivots := rowReduce(rows, len(species))
+
+	// Each free column gives one basis vector of the null space
+	isPivot := make(map[int]bool, len(pivots))
+	for _, col := range pivots {
+		isPivot[col] = true
+	}
+	var laws []map[string]int
+	for free := range species {
+		if isPivot[free] {
+			continue
+		}
+		weights := make([]*big.Rat, len(species))
+		for j := range weights {
+			weights[j] = new(big.Rat)
+		}
+		weights[free].SetInt64(1)
+		for r, col := range pivots {
+			weights[col].Neg(rows[r][free])
+		}
+		laws = append(laws, integerWeights(species, weights))
+	}
+	return laws
+}
+
+// ConservedMoieties returns, for each conservation law, the species taking
+// part in it.
+func (p *Pond) ConservedMoieties() [][]string {
+	laws := p.ConservationLaws()
+	groups := make([][]string, len(laws))
+	for i, law := range laws {
+		for name := range law {
+			groups[i] = append(groups[i], name)
+		}
+		sort.Strings(groups[i])
+	}
+	return groups
+}
+
+// rowReduce brings rows to reduced row echelon form in place and returns the
+// pivot column of each leading row.
+func rowReduce(rows [][]*big.Rat, cols int) []int {
+	var pivots []int
+	r := 0
+	for c := 0; c < cols && r < len(rows); c++ {
+		pivot := -1
+		for i := r; i < len(rows); i++ {
+			if rows[i][c].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		rows[r], rows[pivot] = rows[pivot], rows[r]
+
+		inv := new(big.Rat).Inv(rows[r][c])
+		for j := c; j < cols; j++ {
+			rows[r][j].Mul(rows[r][j], inv)
+		}
+		for i := range rows {
+			if i == r || rows[i][c].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[i][c])
+			for j := c; j < cols; j++ {
+				rows[i][j].Sub(rows[i][j], new(big.Rat).Mul(factor, rows[r][j]))
+			}
+		}
+		pivots = append(pivots, c)
+		r++
+	}
+	return pivots
+}
+
+// integerWeights scales rational weights to the smallest integers, with the
+// first non-zero weight positive, and keys them by species.
+func integerWeights(species []string, weights []*big.Rat) map[string]int {
+	lcm := big.NewInt(1)
+	for _, w := range weights {
+		d := w.Denom()
+		g := new(big.Int).GCD(nil, nil, lcm, d)
+		lcm.Mul(lcm, new(big.Int).Quo(d, g))
+	}
+	ints := make([]*big.Int, len(weights))
+	gcd := new(big.Int)
+	for j, w := range weights {
+		n := new(big.Rat).Mul(w, new(big.Rat).SetInt(lcm))
+		ints[j] = new(big.Int).Set(n.Num())
+		gcd.GCD(nil, nil, gcd, new(big.Int).Abs(ints[j]))
+	}
+
+	sign := int64(1)
+	for _, n := range ints {
+		if n.Sign() != 0 {
+			sign = int64(n.Sign())
+			break
+		}
+	}
+	law := make(map[string]int)
+	for j, n := range ints {
+		if n.Sign() == 0 {
+			continue
+		}
+		n.Quo(n, gcd)
+		law[species[j]] = int(n.Int64() * sign)
+	}
+	return law
+}
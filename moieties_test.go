@@ -0,0 +1,71 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// enzymePond is Michaelis-Menten kinetics, E + S <-> ES -> E + P, which
+// conserves the enzyme (E + ES) and the substrate (S + ES + P).
+func enzymePond(t *testing.T) *Pond {
+	t.Helper()
+	var reactions []Reaction
+	for _, formula := range []string{"E + S -> ES", "ES -> E + S", "ES -> E + P"} {
+		r, err := ParseReaction(formula)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reactions = append(reactions, r)
+	}
+	return testPond(1, map[string]int{"E": 10, "S": 100, "ES": 0, "P": 0}, reactions...)
+}
+
+func TestConservedMoieties(t *testing.T) {
+	p := enzymePond(t)
+	laws := p.ConservationLaws()
+	want := []map[string]int{{"E": 1, "ES": 1}, {"E": 1, "P": -1, "S": -1}}
+	if !reflect.DeepEqual(laws, want) {
+		t.Fatalf("ConservationLaws() = %v, want %v", laws, want)
+	}
+	// The basis spans the substrate law: S + ES + P = (E + ES) - (E - P - S)
+	substrate := map[string]int{}
+	for name, w := range laws[0] {
+		substrate[name] += w
+	}
+	for name, w := range laws[1] {
+		if substrate[name] -= w; substrate[name] == 0 {
+			delete(substrate, name)
+		}
+	}
+	if want := map[string]int{"S": 1, "ES": 1, "P": 1}; !reflect.DeepEqual(substrate, want) {
+		t.Errorf("laws combine to %v, want %v", substrate, want)
+	}
+
+	if got, want := p.ConservedMoieties(), [][]string{{"E", "ES"}, {"E", "P", "S"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ConservedMoieties() = %v, want %v", got, want)
+	}
+}
+
+func TestConservationLawsHoldDuringRun(t *testing.T) {
+	p := enzymePond(t)
+	laws := p.ConservationLaws()
+	if len(laws) != 2 {
+		t.Fatalf("found %d conservation laws, want 2: %v", len(laws), laws)
+	}
+	weighted := func(law map[string]int) int {
+		sum := 0
+		for name, w := range law {
+			sum += w * p.Molecules[name]
+		}
+		return sum
+	}
+	initial := []int{weighted(laws[0]), weighted(laws[1])}
+	for i := 0; i < 5000; i++ {
+		p.Step()
+		for k, law := range laws {
+			if got := weighted(law); got != initial[k] {
+				t.Fatalf("step %d: %v sums to %d, want %d", i, law, got, initial[k])
+			}
+		}
+	}
+}
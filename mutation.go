@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// --- Replicator Mutation ---
+
+// DefaultMutationSpread is the default standard deviation of the log rate change of a mutant.
+const DefaultMutationSpread = 0.2
+
+// mutate, with probability MutationRate, turns the copy just made by
+// autocatalytic reaction idx (one without branches) into a new replicator
+// species. The mutant gets
+// its own copy of the autocatalytic reaction, with the rate multiplied by
+// exp(MutationSpread * N(0,1)), and copies of every other reaction that
+// consumes the parent (e.g. its decay), with the parent renamed. Draws come
+// from the parent reaction's own stream. It returns the mutant's name, or ""
+// when no mutation happened.
+func (p *Pond) mutate(idx int) string {
+	r := p.Reactions[idx]
+	parent := replicatedSpecies(r)
+	if parent == "" || p.Molecules[parent] <= 0 {
+		return ""
+	}
+	rng := p.reactionRand(idx)
+	if rng.Float64() >= p.MutationRate {
+		return ""
+	}
+
+	// Mutants are named after the original replicator: E_1, E_2, ...
+	root := parent
+	if r, ok := p.mutantRoots[parent]; ok {
+		root = r
+	}
+	var mutant string
+	for taken := true; taken; taken = slices.Contains(p.networkSpecies(), mutant) {
+		p.mutants++
+		mutant = fmt.Sprintf("%s_%d", root, p.mutants)
+	}
+	if p.mutantRoots == nil {
+		p.mutantRoots = map[string]string{}
+	}
+	p.mutantRoots[mutant] = root
+
+	// The new copy is the mutant rather than the parent
+	p.Molecules[parent]--
+	p.Molecules[mutant]++
+
+	copied := renameSpecies(r, parent, mutant)
+	copied.Rate = r.EffectiveRate() * math.Exp(p.MutationSpread*rng.NormFloat64())
+	copied.Disabled = false
+	mutantIdx := p.AddReaction(copied)
+	for i, other := range p.Reactions[:mutantIdx] {
+		if i != idx && slices.Contains(other.Reactants, parent) {
+			p.AddReaction(renameSpecies(other, parent, mutant))
+		}
+	}
+
+	if p.Lineage != nil {
+		p.recordConsumed(parent)
+		p.recordProduced(mutant, mutantIdx)
+	}
+	if p.propsCached {
+		p.weights = p.fillPropensities(p.weights) // The network grew
+	}
+	logf(LevelInfo, "step %d: %s mutated into %s (rate %.3g)", p.StepCount, parent, mutant, copied.Rate)
+	return mutant
+}
+
+// replicatedSpecies returns the first product of r that also catalyzes it,
+// or "" if r is not autocatalytic.
+func replicatedSpecies(r Reaction) string {
+	catalysts := r.AllCatalysts()
+	for _, product := range r.AllProducts() {
+		if slices.Contains(catalysts, product) {
+			return product
+		}
+	}
+	return ""
+}
+
+// renameSpecies returns a copy of r with every occurrence of from replaced by to.
+func renameSpecies(r Reaction, from, to string) Reaction {
+	rename := func(names []string) []string {
+		if names == nil {
+			return nil
+		}
+		out := make([]string, len(names))
+		for i, name := range names {
+			out[i] = name
+			if name == from {
+				out[i] = to
+			}
+		}
+		return out
+	}
+
+	out := r
+	out.Reactants = rename(r.Reactants)
+	out.ByProducts = rename(r.ByProducts)
+	out.Catalysts = rename(r.Catalysts)
+	if out.Product == from {
+		out.Product = to
+	}
+	if out.Catalyst == from {
+		out.Catalyst = to
+	}
+	out.Branches = nil
+	for _, b := range r.Branches {
+		out.Branches = append(out.Branches, Branch{Products: rename(b.Products), Probability: b.Probability})
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMutationCreatesReplicator(t *testing.T) {
+	cfg := CompetitionConfig([]float64{1})
+	cfg.Seed = 4
+	p := cfg.NewPond()
+	p.MutationRate = 0.01
+	p.MutationSpread = DefaultMutationSpread
+	p.Gillespie = true
+	p.Run(20000)
+
+	const mutant = "E1_1" // The first mutant of E1
+	if !slices.Contains(p.Replicators(), mutant) {
+		t.Fatalf("no mutant replicator after 20000 steps: %v", p.Replicators())
+	}
+	if _, ok := p.Molecules[mutant]; !ok {
+		t.Errorf("%s is not registered in Molecules", mutant)
+	}
+
+	var copying, decay *Reaction
+	for i, r := range p.Reactions {
+		switch {
+		case r.Product == mutant && slices.Equal(r.Catalysts, []string{mutant}):
+			copying = &p.Reactions[i]
+		case slices.Equal(r.Reactants, []string{mutant}) && r.Product == "A":
+			decay = &p.Reactions[i]
+		}
+	}
+	if copying == nil || !slices.Equal(copying.Reactants, []string{"D"}) {
+		t.Errorf("no autocatalytic reaction D -> %s (cat %s) in %v", mutant, mutant, p.Reactions)
+	} else if copying.Rate <= 0 || copying.Rate == 1 {
+		t.Errorf("mutant copying rate %v, want a perturbed positive rate", copying.Rate)
+	}
+	if decay == nil {
+		t.Errorf("no decay reaction %s -> A in %v", mutant, p.Reactions)
+	}
+}
+
+func TestNoMutationWithoutRate(t *testing.T) {
+	cfg := CompetitionConfig([]float64{1})
+	p := cfg.NewPond()
+	p.Gillespie = true
+	p.Run(50000)
+	if got := p.Replicators(); !slices.Equal(got, []string{"E1"}) {
+		t.Errorf("replicators %v without mutation, want only E1", got)
+	}
+}
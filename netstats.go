@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Network Statistics ---
+
+// NetworkStats summarizes the shape of the reaction network.
+type NetworkStats struct {
+	Species       int     // Species in the pond or mentioned by a reaction
+	Reactions     int     // Reactions, enabled or not
+	Autocatalytic int     // Reactions catalyzed by one of their own products
+	MeanReactants float64 // Average number of reactants per reaction
+	Connected     bool    // Every species is linked to every other through some reaction
+}
+
+// NetworkStats computes the network's statistics. Species and reactions form
+// a graph in which a reaction links its reactants, products and catalysts;
+// the network is connected when that graph has a single component.
+func (p *Pond) NetworkStats() NetworkStats {
+	species := p.networkSpecies()
+	stats := NetworkStats{Species: len(species), Reactions: len(p.Reactions)}
+
+	reactants := 0
+	for _, r := range p.Reactions {
+		reactants += len(r.Reactants)
+		if r.IsAutocatalytic() {
+			stats.Autocatalytic++
+		}
+	}
+	if stats.Reactions > 0 {
+		stats.MeanReactants = float64(reactants) / float64(stats.Reactions)
+	}
+
+	// Union-find over species, merging everything each reaction touches
+	parent := make(map[string]string, len(species))
+	for _, name := range species {
+		parent[name] = name
+	}
+	var find func(string) string
+	find = func(name string) string {
+		if parent[name] != name {
+			parent[name] = find(parent[name])
+		}
+		return parent[name]
+	}
+	components := len(species)
+	for _, r := range p.Reactions {
+		names := append(append(r.AllProducts(), r.Reactants...), r.AllCatalysts()...)
+		if len(names) == 0 {
+			continue
+		}
+		for _, name := range names[1:] {
+			if a, b := find(names[0]), find(name); a != b {
+				parent[b] = a
+				components--
+			}
+		}
+	}
+	stats.Connected = components == 1
+	return stats
+}
+
+// Lines formats the stats as the rows of the on-screen panel.
+func (s NetworkStats) Lines() []string {
+	return []string{
+		fmt.Sprintf("Species:        %d", s.Species),
+		fmt.Sprintf("Reactions:      %d", s.Reactions),
+		fmt.Sprintf("Autocatalytic:  %d", s.Autocatalytic),
+		fmt.Sprintf("Reactants/rxn:  %.2f", s.MeanReactants),
+		fmt.Sprintf("Connected:      %t", s.Connected),
+	}
+}
+
+// String formats the stats on one line.
+func (s NetworkStats) String() string {
+	return fmt.Sprintf("species %d | reactions %d | autocatalytic %d | reactants/reaction %.2f | connected %t",
+		s.Species, s.Reactions, s.Autocatalytic, s.MeanReactants, s.Connected)
+}
+
+// Placement of the network statistics panel, over the right of the molecule table.
+const (
+	netStatsPanelWidth = 190
+	netStatsPanelX     = ScreenWidth - netStatsPanelWidth - 20
+	netStatsPanelY     = tableHeaderY - 15
+)
+
+// drawNetworkStats draws the network statistics panel.
+func (g *Game) drawNetworkStats(screen *ebiten.Image) {
+	lines := g.Pond.NetworkStats().Lines()
+	height := float32(20 + tableRowStep*len(lines))
+	vector.FillRect(screen, netStatsPanelX, netStatsPanelY, netStatsPanelWidth, height, color.RGBA{20, 20, 40, 230}, false)
+	vector.StrokeRect(screen, netStatsPanelX, netStatsPanelY, netStatsPanelWidth, height, 1, g.Theme.Header, false)
+
+	y := netStatsPanelY + 15
+	text.Draw(screen, "Network", basicfont.Face7x13, netStatsPanelX+10, y, g.Theme.Header)
+	for _, line := range lines {
+		y += tableRowStep
+		text.Draw(screen, line, basicfont.Face7x13, netStatsPanelX+10, y, color.White)
+	}
+}
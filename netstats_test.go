@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestNetworkStatsDefaultPond(t *testing.T) {
+	got := NewPond().NetworkStats()
+	want := NetworkStats{Species: 5, Reactions: 4, Autocatalytic: 1, MeanReactants: 1.75, Connected: true}
+	if got != want {
+		t.Errorf("NetworkStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNetworkStatsDisconnected(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 1, "B": 1, "X": 1, "Y": 1},
+		Reaction{Reactants: []string{"A"}, Product: "B"},
+		Reaction{Reactants: []string{"X"}, Product: "Y"},
+	)
+	if got := p.NetworkStats(); got.Connected || got.Species != 4 || got.Autocatalytic != 0 {
+		t.Errorf("NetworkStats() = %+v, want 4 unconnected species and no autocatalysis", got)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "slices"
+
+// --- Noisy Kinetics ---
+
+// NoisyRates draws this step's effective rate of every reaction: the nominal
+// (inhibited) rate times (1 + RateNoise*N(0,1)), clamped at zero so noise can switch a
+// reaction off for a step but never make its rate negative.
+func (p *Pond) NoisyRates() []float64 {
+	return p.fillNoisyRates(make([]float64, len(p.Reactions)))
+}
+
+// fillNoisyRates draws the noisy rates into buf, growing it if needed, and
+// returns it.
+func (p *Pond) fillNoisyRates(buf []float64) []float64 {
+	rates := slices.Grow(buf[:0], len(p.Reactions))[:len(p.Reactions)]
+	for i, r := range p.Reactions {
+		rate := p.InhibitedRate(r) * (1 + p.RateNoise*p.reactionRand(i).NormFloat64())
+		if rate < 0 {
+			rate = 0
+		}
+		rates[i] = rate
+	}
+	return rates
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestZeroRateNoiseMatchesBaseline(t *testing.T) {
+	baseline, quiet := NewPondWithSeed(7), NewPondWithSeed(7)
+	quiet.RateNoise = 0
+	for i := 0; i < 10000; i++ {
+		baseline.Step()
+		quiet.Step()
+	}
+	if !maps.Equal(baseline.Molecules, quiet.Molecules) {
+		t.Errorf("counts differ: %v vs %v", baseline.Molecules, quiet.Molecules)
+	}
+	for i, rate := range quiet.NoisyRates() {
+		if want := quiet.InhibitedRate(quiet.Reactions[i]); rate != want {
+			t.Errorf("reaction %d: noisy rate %v without noise, want %v", i, rate, want)
+		}
+	}
+}
+
+func TestRateNoiseVariesButStaysNonNegative(t *testing.T) {
+	p := NewPondWithSeed(7)
+	p.RateNoise = 2 // Large enough that some draws would go negative unclamped
+	seen := map[float64]bool{}
+	zeros := 0
+	for i := 0; i < 1000; i++ {
+		rate := p.NoisyRates()[0]
+		if rate < 0 {
+			t.Fatalf("draw %d: negative rate %v", i, rate)
+		}
+		if rate == 0 {
+			zeros++
+		}
+		seen[rate] = true
+	}
+	if len(seen) < 100 {
+		t.Errorf("only %d distinct rates in 1000 draws", len(seen))
+	}
+	if zeros == 0 {
+		t.Error("no draw was clamped to zero")
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+// --- Tick Observers ---
+
+// AddObserver registers fn to be called after every tick with the tick
+// number and the pond. Observers must treat the pond as read-only; they run
+// in registration order.
+func (p *Pond) AddObserver(fn func(tick int, p *Pond)) {
+	p.observers = append(p.observers, fn)
+}
+
+// notifyObservers records the tick's statistics and calls every registered
+// observer for the given tick.
+func (p *Pond) notifyObservers(tick int) {
+	p.stats.observe(p.Molecules)
+	for _, fn := range p.observers {
+		fn(tick, p)
+	}
+}
+
+// AddFireObserver registers fn to be called after every successful fire
+// with the reaction index and the branch taken (-1 if none). It runs on
+// every fire, so it must be cheap.
+func (p *Pond) AddFireObserver(fn func(reaction, branch int)) {
+	p.fireObservers = append(p.fireObservers, fn)
+}
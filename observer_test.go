@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObserverCalledOncePerTick(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(1))
+	var ticks []int
+	g.Pond.AddObserver(func(tick int, p *Pond) {
+		if p != g.Pond {
+			t.Errorf("tick %d: observer got another pond", tick)
+		}
+		ticks = append(ticks, tick)
+	})
+	for i := 0; i < 5; i++ {
+		g.advance(10)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(ticks, want) {
+		t.Errorf("observer saw ticks %v, want %v", ticks, want)
+	}
+}
+
+func TestObserversRunInOrder(t *testing.T) {
+	p := NewPondWithSeed(1)
+	var order []string
+	p.AddObserver(func(int, *Pond) { order = append(order, "first") })
+	p.AddObserver(func(int, *Pond) { order = append(order, "second") })
+	p.notifyObservers(1)
+	if want := []string{"first", "second"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("observers ran as %v, want %v", order, want)
+	}
+}
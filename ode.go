@@ -0,0 +1,202 @@
+package main
+
+import "math"
+
+// --- Deterministic (ODE) Mode ---
+
+// odeEligible reports whether Run should integrate the rate equations rather
+// than fire reactions one at a time: ODE mode is enabled and every species
+// consumed by an enabled reaction has at least ODEThreshold molecules, so
+// counting noise is negligible. A network with a reaction making a capped
+// species is stepped stochastically, as integration cannot honour the cap.
+func (p *Pond) odeEligible() bool {
+	if p.ODEThreshold <= 0 || len(p.Reactions) == 0 {
+		return false
+	}
+	for _, r := range p.Reactions {
+		if r.Disabled {
+			continue
+		}
+		if p.producesCapped(r) {
+			return false
+		}
+		for _, name := range r.Reactants {
+			if p.Molecules[name] < p.ODEThreshold {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// integrateODE advances the pond deterministically by the time n fires would
+// take on average at the current rates, using one classic fourth-order
+// Runge-Kutta step of the mass-action rate equations. Amounts keeps the
+// fractional counts between calls, so slow reactions are not lost to
+// rounding; Molecules holds the rounded counts. Sequestration, mutation and
+// lineage tracking only apply to individual fires and are not modelled.
+func (p *Pond) integrateODE(n int) {
+	species, pos, x := p.odeState()
+	fixed := p.fixedRates()
+	total := 0.0
+	for j := range p.Reactions {
+		total += p.odeFlux(j, x, pos, fixed)
+	}
+	p.StepCount += n
+	if total <= 0 {
+		return
+	}
+	dt := float64(n) / total
+	p.rk4(x, pos, fixed, nil, dt)
+	p.Time += dt
+	p.storeAmounts(species, x)
+}
+
+// odeState returns every species in the network, its position in the state
+// vector and its current amount. Counts changed from outside since the last
+// integration (injection, knockdown) override the fractional amount.
+func (p *Pond) odeState() ([]string, map[string]int, []float64) {
+	species := p.networkSpecies()
+	pos := make(map[string]int, len(species))
+	x := make([]float64, len(species))
+	for i, name := range species {
+		pos[name] = i
+		amount, ok := p.Amounts[name]
+		if !ok || int(math.Round(amount)) != p.Molecules[name] {
+			amount = float64(p.Molecules[name])
+		}
+		x[i] = amount
+	}
+	return species, pos, x
+}
+
+// fixedRates returns the current rate of every reaction with a rate law.
+// Rate laws read the integer counts, so they are held fixed over a step.
+func (p *Pond) fixedRates() []float64 {
+	fixed := make([]float64, len(p.Reactions))
+	for j, r := range p.Reactions {
+		if r.RateLaw != nil {
+			fixed[j] = p.Propensity(j)
+		}
+	}
+	return fixed
+}
+
+// rk4 advances x in place by dt under the reactions for which include is
+// true (all of them when include is nil), clamping amounts at zero.
+func (p *Pond) rk4(x []float64, pos map[string]int, fixed []float64, include []bool, dt float64) {
+	k1 := p.odeDerivative(x, pos, fixed, include)
+	k2 := p.odeDerivative(axpy(x, dt/2, k1), pos, fixed, include)
+	k3 := p.odeDerivative(axpy(x, dt/2, k2), pos, fixed, include)
+	k4 := p.odeDerivative(axpy(x, dt, k3), pos, fixed, include)
+	for i := range x {
+		x[i] = math.Max(x[i]+dt/6*(k1[i]+2*k2[i]+2*k3[i]+k4[i]), 0)
+	}
+}
+
+// storeAmounts records the integrated amounts and their rounded counts,
+// discarding any amount above a species' MaxCount.
+func (p *Pond) storeAmounts(species []string, x []float64) {
+	if p.Amounts == nil {
+		p.Amounts = make(map[string]float64, len(species))
+	}
+	for i, name := range species {
+		if limit, ok := p.MaxCount[name]; ok && x[i] > float64(limit) {
+			x[i] = float64(limit)
+		}
+		p.Amounts[name] = x[i]
+		count := int(math.Round(x[i]))
+		if _, ok := p.Molecules[name]; ok || count > 0 {
+			p.Molecules[name] = count
+		}
+	}
+}
+
+// odeFlux returns the deterministic rate of reaction j at amounts x: the
+// large-count limit of its propensity, with x^k/k! in place of n choose k.
+// Reactions with a rate law use their precomputed fixed rate.
+func (p *Pond) odeFlux(j int, x []float64, pos map[string]int, fixed []float64) float64 {
+	r := p.Reactions[j]
+	if r.RateLaw != nil {
+		return fixed[j]
+	}
+	if r.MinTotalPopulation > 0 && p.TotalPopulation() < r.MinTotalPopulation {
+		return 0
+	}
+	if q := r.MinReactantRatio; q != nil && !q.Met(x[pos[q.Numerator]], x[pos[q.Denominator]]) {
+		return 0
+	}
+	flux := r.EffectiveRate()
+	if r.SuccessProbability > 0 {
+		flux *= r.SuccessProbability
+	}
+	if r.ProductInhibition > 0 {
+		flux /= r.inhibition(x[pos[r.Product]])
+	}
+	for k, reactant := range r.Reactants {
+		// The m-th copy of a repeated reactant contributes x/m
+		occurrence := 1
+		for _, other := range r.Reactants[:k] {
+			if other == reactant {
+				occurrence++
+			}
+		}
+		flux *= x[pos[reactant]] / float64(occurrence)
+	}
+	catalysts := r.AllCatalysts()
+	for _, catalyst := range catalysts {
+		flux *= x[pos[catalyst]]
+	}
+	if order := len(r.Reactants) + len(catalysts); order > 1 {
+		flux /= math.Pow(p.volume(), float64(order-1))
+	}
+	return flux
+}
+
+// odeDerivative returns the rate of change of every amount at x due to the
+// included reactions. A branching reaction splits its flux between branches
+// by probability, as firedBranch does.
+func (p *Pond) odeDerivative(x []float64, pos map[string]int, fixed []float64, include []bool) []float64 {
+	dxdt := make([]float64, len(x))
+	for j, r := range p.Reactions {
+		if include != nil && !include[j] {
+			continue
+		}
+		flux := p.odeFlux(j, x, pos, fixed)
+		if flux == 0 {
+			continue
+		}
+		for _, reactant := range r.Reactants {
+			dxdt[pos[reactant]] -= flux
+		}
+		if len(r.Branches) == 0 {
+			for _, product := range r.YieldedProducts() {
+				dxdt[pos[product]] += flux
+			}
+			continue
+		}
+		totalWeight := 0.0
+		for _, b := range r.Branches {
+			totalWeight += max(b.Probability, 0)
+		}
+		for _, b := range r.Branches {
+			share := 1 / float64(len(r.Branches)) // No usable probabilities: equally likely
+			if totalWeight > 0 {
+				share = max(b.Probability, 0) / totalWeight
+			}
+			for _, product := range b.Products {
+				dxdt[pos[product]] += flux * share
+			}
+		}
+	}
+	return dxdt
+}
+
+// axpy returns x + a*y as a new slice.
+func axpy(x []float64, a float64, y []float64) []float64 {
+	out := make([]float64, len(x))
+	for i := range x {
+		out[i] = x[i] + a*y[i]
+	}
+	return out
+}
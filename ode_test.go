@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestODEMatchesExponentialDecay(t *testing.T) {
+	const a0, rate = 1e6, 0.5
+	p := testPond(1, map[string]int{"A": a0, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B", Rate: rate})
+	p.ODEThreshold = 1000
+
+	for i := 0; i < 50; i++ {
+		p.Run(10000)
+		if p.Amounts == nil {
+			t.Fatalf("tick %d: pond left ODE mode with A = %d", i, p.Molecules["A"])
+		}
+		want := a0 * math.Exp(-rate*p.Time)
+		if got := p.Amounts["A"]; math.Abs(got-want) > 1e-6*want {
+			t.Fatalf("t=%.4f: A = %.3f, want %.3f", p.Time, got, want)
+		}
+		if got := p.Amounts["A"] + p.Amounts["B"]; math.Abs(got-a0) > 1e-6 {
+			t.Fatalf("t=%.4f: A + B = %.3f, want %v", p.Time, got, a0)
+		}
+	}
+	if p.Time == 0 || p.Molecules["A"] != int(math.Round(p.Amounts["A"])) {
+		t.Errorf("after integration Time = %v, A = %d (amount %.3f)", p.Time, p.Molecules["A"], p.Amounts["A"])
+	}
+}
+
+func TestODEFallsBackBelowThreshold(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 500, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B"})
+	p.ODEThreshold = 1000
+	p.Run(100)
+	if p.Amounts != nil {
+		t.Errorf("Amounts = %v below the threshold, want stochastic stepping", p.Amounts)
+	}
+	if got := p.Molecules["A"] + p.Molecules["B"]; got != 500 {
+		t.Errorf("A + B = %d, want 500", got)
+	}
+}
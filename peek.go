@@ -0,0 +1,37 @@
+package main
+
+import "maps"
+
+// --- Dry Step ---
+
+// PeekStep reports the reaction the next Step would attempt and whether it
+// would fire, without changing the pond. Selection runs on a copy with cloned
+// random sources; catalysts due for release are returned into a copy of the
+// counts. reaction is the zero Reaction when nothing would be attempted.
+func (p *Pond) PeekStep() (reaction Reaction, willFire bool) {
+	if len(p.Reactions) == 0 {
+		return Reaction{}, false
+	}
+
+	q := *p
+	q.weights, q.propsCached = nil, false // Never touch p's cached propensities
+	q.rng = p.rng.Clone()
+	q.reactionRands = make([]*cloneableRand, len(p.reactionRands))
+	for i, rng := range p.reactionRands {
+		if rng != nil {
+			q.reactionRands[i] = rng.Clone()
+		}
+	}
+	q.StepCount++
+	if len(p.pending) > 0 && p.pending[0].Due <= q.StepCount {
+		q.Molecules = maps.Clone(p.Molecules)
+		q.Lineage = nil
+		q.releaseSequestered()
+	}
+
+	idx := q.selectReaction()
+	if idx < 0 {
+		return Reaction{}, false
+	}
+	return q.Reactions[idx], q.canFire(q.Reactions[idx]) && q.succeeds(idx)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestPeekStepHasNoSideEffects(t *testing.T) {
+	modes := map[string]func(p *Pond){
+		"uniform":         func(p *Pond) {},
+		"noisy":           func(p *Pond) { p.RateNoise = 0.3 },
+		"gillespie":       func(p *Pond) { p.Gillespie = true },
+		"delayed release": func(p *Pond) { p.Reactions[2].CatalystDelay = 3 },
+	}
+	for name, setup := range modes {
+		t.Run(name, func(t *testing.T) {
+			peeked, plain := NewPondWithSeed(3), NewPondWithSeed(3)
+			setup(peeked)
+			setup(plain)
+			for i := 0; i < 3000; i++ {
+				counts := maps.Clone(peeked.Molecules)
+				_, willFire := peeked.PeekStep()
+				if again, _ := peeked.PeekStep(); !maps.Equal(counts, peeked.Molecules) {
+					t.Fatalf("step %d: peeking %v changed counts", i, again)
+				}
+
+				fires := peeked.TotalFires()
+				peeked.Step()
+				plain.Step()
+				if fired := peeked.TotalFires() > fires; fired != willFire {
+					t.Fatalf("step %d: peek predicted fire %t, step fired %t", i, willFire, fired)
+				}
+				if !maps.Equal(peeked.Molecules, plain.Molecules) || peeked.Time != plain.Time {
+					t.Fatalf("step %d: peeking changed the trajectory", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// --- Manual Perturbations ---
+
+// DefaultKnockdownFraction is the share of a species removed by one knockdown.
+const DefaultKnockdownFraction = 0.5
+
+// knockdown returns what is left of count after removing the given fraction,
+// with the removed amount rounded by round. The fraction is clamped to [0,1]
+// and the result never goes negative.
+func knockdown(count int, fraction float64, round func(float64) int) int {
+	if count <= 0 {
+		return 0
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	return max(count-round(float64(count)*fraction), 0)
+}
+
+// Knockdown removes a fraction of a species from the pond and returns how many
+// molecules were removed.
+func (p *Pond) Knockdown(species string, fraction float64) int {
+	count := p.Molecules[species]
+	remaining := knockdown(count, fraction, p.round)
+	removed := count - remaining
+	p.Molecules[species] = remaining
+	if p.Lineage != nil {
+		for i := 0; i < removed; i++ {
+			p.recordConsumed(species)
+		}
+	}
+	return removed
+}
+
+// Inject parses a "species amount" line such as "E 250" and adds that many
+// molecules to the pond. The species must already exist and the amount must
+// be a non-negative integer.
+func (p *Pond) Inject(input string) (species string, amount int, err error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return "", 0, fmt.Errorf("expected \"species amount\", got %q", input)
+	}
+	species = fields[0]
+	if _, ok := p.Molecules[species]; !ok {
+		return "", 0, fmt.Errorf("unknown species %q", species)
+	}
+	amount, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid amount %q", fields[1])
+	}
+	if amount < 0 {
+		return "", 0, fmt.Errorf("amount must not be negative, got %d", amount)
+	}
+
+	p.Molecules[species] += amount
+	if p.Lineage != nil {
+		for i := 0; i < amount; i++ {
+			p.recordProduced(species, LineageInitial) // Added from outside, like the initial stock
+		}
+	}
+	return species, amount, nil
+}
+
+// updateInjection handles typing in injection mode: Enter applies the line,
+// Escape cancels and Backspace deletes.
+func (g *Game) updateInjection() {
+	g.InjectInput = string(ebiten.AppendInputChars([]rune(g.InjectInput)))
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		g.Injecting = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		if r := []rune(g.InjectInput); len(r) > 0 {
+			g.InjectInput = string(r[:len(r)-1])
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		g.Injecting = false
+		species, amount, err := g.Pond.Inject(g.InjectInput)
+		if err != nil {
+			g.Pond.Status = fmt.Sprintf("Injection failed: %v", err)
+		} else {
+			g.Pond.Status = fmt.Sprintf("Injected %d %s", amount, species)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import "testing"
+
+func TestKnockdown(t *testing.T) {
+	round := testPond(1, map[string]int{}).round
+	tests := []struct {
+		count    int
+		fraction float64
+		want     int
+	}{
+		{1000, 0.5, 500},
+		{1, 0.5, 0},
+		{0, 0.5, 0},
+		{-3, 0.5, 0},
+		{1000, 0.25, 750},
+		{1000, 0, 1000},
+		{1000, 1, 0},
+		{1000, 2, 0},     // Fraction clamped to 1
+		{1000, -1, 1000}, // Fraction clamped to 0
+	}
+	for _, tt := range tests {
+		if got := knockdown(tt.count, tt.fraction, round); got != tt.want {
+			t.Errorf("knockdown(%d, %v) = %d, want %d", tt.count, tt.fraction, got, tt.want)
+		}
+	}
+}
+
+func TestPondKnockdown(t *testing.T) {
+	p := testPond(1, map[string]int{"E": 1000})
+	if removed := p.Knockdown("E", DefaultKnockdownFraction); removed != 500 {
+		t.Errorf("removed %d, want 500", removed)
+	}
+	if p.Molecules["E"] != 500 {
+		t.Errorf("E = %d after knockdown, want 500", p.Molecules["E"])
+	}
+}
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		input   string
+		species string
+		amount  int
+		wantErr bool
+	}{
+		{"E 250", "E", 250, false},
+		{"  A   0 ", "A", 0, false},
+		{"Z 10", "", 0, true},  // Unknown species
+		{"E -5", "", 0, true},  // Negative amount
+		{"E ten", "", 0, true}, // Not an integer
+		{"E", "", 0, true},     // Missing amount
+		{"E 1 2", "", 0, true}, // Extra field
+	}
+	for _, tt := range tests {
+		p := NewPondWithSeed(1)
+		before := p.Molecules["E"]
+		species, amount, err := p.Inject(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Inject(%q) error = %v, want error %t", tt.input, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			if p.Molecules["E"] != before {
+				t.Errorf("Inject(%q) changed E to %d despite the error", tt.input, p.Molecules["E"])
+			}
+			continue
+		}
+		if species != tt.species || amount != tt.amount {
+			t.Errorf("Inject(%q) = %s, %d; want %s, %d", tt.input, species, amount, tt.species, tt.amount)
+		}
+	}
+
+	p := NewPondWithSeed(1)
+	before := p.Molecules["E"]
+	if _, _, err := p.Inject("E 250"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Molecules["E"] != before+250 {
+		t.Errorf("E = %d after injecting 250, want %d", p.Molecules["E"], before+250)
+	}
+}
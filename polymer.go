@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"unicode/utf8"
+)
+
+// --- Polymer World ---
+
+// Defaults for the polymer-world chemistry.
+const (
+	DefaultMaxPolymerLength     = 5
+	DefaultCatalystMinLength    = 3
+	DefaultCatalysisProbability = 0.05
+	DefaultLigationRate         = 0.1
+	DefaultCleavageRate         = 0.05
+	DefaultCatalyzedRate        = 5
+)
+
+// catalysisSeedOffset separates the catalyst assignment stream from the pond's main stream.
+const catalysisSeedOffset = 0x636174 // "cat"
+
+// PolymerPond is a combinatorial chemistry on top of Pond. Species are
+// strings of single-letter monomers; every pair of present species can ligate
+// (X + Y -> XY) and every polymer can cleave at any bond (XY -> X + Y). Each
+// polymer of at least CatalystMinLength catalyzes a random subset of the
+// ligations, as a faster copy of the uncatalyzed reaction. Reactions are
+// generated as species first appear, so the network grows with the pond.
+type PolymerPond struct {
+	*Pond
+
+	MaxLength            int     // Longest polymer a ligation may build
+	CatalystMinLength    int     // Shortest polymer that can act as a catalyst
+	CatalysisProbability float64 // Chance a catalyst polymer catalyzes a given ligation
+	LigationRate         float64 // Rate of uncatalyzed ligations
+	CleavageRate         float64 // Rate of every cleavage
+	CatalyzedRate        float64 // Rate of catalyzed ligations
+
+	expanded      map[string]bool // Species whose reactions have been generated
+	ligations     [][2]string     // Every uncatalyzed ligation, as (left, right) parts
+	catalysisRand *rand.Rand
+}
+
+// NewPolymerPond creates a polymer world seeded with the given monomers,
+// whose names must be single letters.
+func NewPolymerPond(monomers map[string]int, seed int64) (*PolymerPond, error) {
+	molecules := make(map[string]int, len(monomers))
+	for name, count := range monomers {
+		if utf8.RuneCountInString(name) != 1 || !isSpeciesName(name) {
+			return nil, fmt.Errorf("monomer %q is not a single letter", name)
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("monomer %q has negative count %d", name, count)
+		}
+		molecules[name] = count
+	}
+
+	pp := &PolymerPond{
+		Pond: &Pond{
+			Seed:      seed,
+			Molecules: molecules,
+			Status:    message(MsgInitialized),
+			rng:       newCloneableRand(seed),
+		},
+		MaxLength:            DefaultMaxPolymerLength,
+		CatalystMinLength:    DefaultCatalystMinLength,
+		CatalysisProbability: DefaultCatalysisProbability,
+		LigationRate:         DefaultLigationRate,
+		CleavageRate:         DefaultCleavageRate,
+		CatalyzedRate:        DefaultCatalyzedRate,
+		expanded:             map[string]bool{},
+		catalysisRand:        rand.New(rand.NewSource(seed + catalysisSeedOffset)),
+	}
+	pp.expand()
+	return pp, nil
+}
+
+// Step runs one step of the underlying pond, then generates the reactions of
+// any species it produced for the first time.
+func (pp *PolymerPond) Step() {
+	pp.Pond.Step()
+	if len(pp.Molecules) > len(pp.expanded) {
+		pp.expand()
+	}
+}
+
+// Run performs n steps.
+func (pp *PolymerPond) Run(n int) {
+	for i := 0; i < n; i++ {
+		pp.Step()
+	}
+}
+
+// expand generates the ligations, cleavages and catalyzed ligations involving
+// each species not yet expanded, in name order so a seed fixes the network.
+func (pp *PolymerPond) expand() {
+	for _, x := range pp.SpeciesNames() {
+		if pp.expanded[x] {
+			continue
+		}
+		pp.expanded[x] = true
+
+		// Cleavage at every bond
+		runes := []rune(x)
+		for k := 1; k < len(runes); k++ {
+			pp.Reactions = append(pp.Reactions, Reaction{
+				Reactants:  []string{x},
+				Product:    string(runes[:k]),
+				ByProducts: []string{string(runes[k:])},
+				Rate:       pp.CleavageRate,
+			})
+		}
+
+		// Ligation with every expanded species, in both orders
+		var fresh [][2]string
+		for _, y := range pp.expandedNames() {
+			fresh = append(fresh, [2]string{x, y})
+			if y != x {
+				fresh = append(fresh, [2]string{y, x})
+			}
+		}
+		existing := len(pp.ligations)
+		for _, parts := range fresh {
+			if utf8.RuneCountInString(parts[0])+utf8.RuneCountInString(parts[1]) > pp.MaxLength {
+				continue
+			}
+			pp.ligations = append(pp.ligations, parts)
+			pp.Reactions = append(pp.Reactions, ligation(parts, pp.LigationRate))
+			for _, c := range pp.expandedNames() {
+				pp.maybeCatalyze(parts, c)
+			}
+		}
+
+		// As a new catalyst, x may also speed up the ligations that already existed
+		for _, parts := range pp.ligations[:existing] {
+			pp.maybeCatalyze(parts, x)
+		}
+	}
+}
+
+// maybeCatalyze adds a catalyzed copy of a ligation with catalyst c, with
+// CatalysisProbability, when c is long enough to be a catalyst.
+func (pp *PolymerPond) maybeCatalyze(parts [2]string, c string) {
+	if utf8.RuneCountInString(c) < pp.CatalystMinLength {
+		return
+	}
+	if pp.catalysisRand.Float64() >= pp.CatalysisProbability {
+		return
+	}
+	r := ligation(parts, pp.CatalyzedRate)
+	r.Catalysts = []string{c}
+	pp.Reactions = append(pp.Reactions, r)
+}
+
+// expandedNames returns the expanded species, sorted.
+func (pp *PolymerPond) expandedNames() []string {
+	names := make([]string, 0, len(pp.expanded))
+	for name := range pp.expanded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ligation returns the reaction joining parts[0] and parts[1] into one polymer.
+func ligation(parts [2]string, rate float64) Reaction {
+	return Reaction{
+		Reactants: []string{parts[0], parts[1]},
+		Product:   parts[0] + parts[1],
+		Rate:      rate,
+	}
+}
+
+// MonomerCounts returns the number of units of each monomer across all
+// polymers in the pond. Ligation and cleavage conserve it.
+func (pp *PolymerPond) MonomerCounts() map[string]int {
+	units := make(map[string]int)
+	for name, count := range pp.Molecules {
+		for _, m := range name {
+			units[string(m)] += count
+		}
+	}
+	return units
+}
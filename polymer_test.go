@@ -0,0 +1,72 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestPolymerLigationConservesMonomers(t *testing.T) {
+	pp, err := NewPolymerPond(map[string]int{"A": 200, "B": 200}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := pp.MonomerCounts()
+	pp.Run(20000)
+	if after := pp.MonomerCounts(); !maps.Equal(before, after) {
+		t.Errorf("monomer units changed from %v to %v", before, after)
+	}
+	if len(pp.Molecules) <= 2 {
+		t.Errorf("no polymers formed: %v", pp.Molecules)
+	}
+	for name, count := range pp.Molecules {
+		if count < 0 {
+			t.Errorf("%s has negative count %d", name, count)
+		}
+	}
+}
+
+func TestPolymerCleavageInvertsLigation(t *testing.T) {
+	pp, err := NewPolymerPond(map[string]int{"A": 10, "B": 10}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ligate := -1
+	for i, r := range pp.Reactions {
+		if len(r.Reactants) == 2 && r.Reactants[0] == "A" && r.Reactants[1] == "B" && len(r.Catalysts) == 0 {
+			ligate = i
+		}
+	}
+	if ligate < 0 {
+		t.Fatal("no A + B ligation generated")
+	}
+	before := maps.Clone(pp.Molecules)
+	pp.fire(ligate)
+	pp.expand()
+	if pp.Molecules["AB"] != 1 {
+		t.Fatalf("AB = %d after ligation, want 1", pp.Molecules["AB"])
+	}
+
+	cleave := -1
+	for i, r := range pp.Reactions {
+		if len(r.Reactants) == 1 && r.Reactants[0] == "AB" {
+			cleave = i
+		}
+	}
+	if cleave < 0 {
+		t.Fatal("no cleavage of AB generated")
+	}
+	if r := pp.Reactions[cleave]; r.Product != "A" || len(r.ByProducts) != 1 || r.ByProducts[0] != "B" {
+		t.Fatalf("cleavage of AB is %v, want AB -> A + B", r)
+	}
+	pp.fire(cleave)
+	delete(pp.Molecules, "AB") // Registered by the ligation, now at zero
+	if !maps.Equal(before, pp.Molecules) {
+		t.Errorf("counts after ligation and cleavage = %v, want %v", pp.Molecules, before)
+	}
+}
+
+func TestNewPolymerPondRejectsLongMonomers(t *testing.T) {
+	if _, err := NewPolymerPond(map[string]int{"AB": 10}, 1); err == nil {
+		t.Error("accepted a two-letter monomer")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import "strings"
+
+// --- Molecule Pools ---
+
+// BulkPool is the pool a species is in unless its name says otherwise.
+const BulkPool = "bulk"
+
+// A pond's molecules can be split into named pools, e.g. membrane-bound
+// versus free. The pool is part of the molecule's key: "E@membrane" is E in
+// the membrane pool, and a bare "E" is E in the bulk pool, so ponds that
+// never mention pools behave as before. Reactions involving pooled species
+// name them directly, or set FromPool and ToPool (see Reaction.Pooled).
+
+// PoolKey returns the molecule key of species in pool.
+func PoolKey(species, pool string) string {
+	if pool == "" || pool == BulkPool {
+		return species
+	}
+	return species + "@" + pool
+}
+
+// SplitPoolKey returns the species and pool a molecule key refers to.
+func SplitPoolKey(key string) (species, pool string) {
+	species, pool, ok := strings.Cut(key, "@")
+	if !ok {
+		return key, BulkPool
+	}
+	return species, pool
+}
+
+// Pooled returns r with FromPool and ToPool resolved: its reactants and
+// catalysts are taken from FromPool and its products released into ToPool,
+// so a reaction "E -> E" from "bulk" to "membrane" moves one E across. Names
+// that already carry a pool are left as they are. Without pools it returns
+// r unchanged.
+func (r Reaction) Pooled() Reaction {
+	if r.FromPool == "" && r.ToPool == "" {
+		return r
+	}
+	r = cloneReaction(r)
+	into := func(name, pool string) string {
+		if name == "" || strings.Contains(name, "@") {
+			return name
+		}
+		return PoolKey(name, pool)
+	}
+	for i := range r.Reactants {
+		r.Reactants[i] = into(r.Reactants[i], r.FromPool)
+	}
+	for i := range r.Catalysts {
+		r.Catalysts[i] = into(r.Catalysts[i], r.FromPool)
+	}
+	r.Catalyst = into(r.Catalyst, r.FromPool)
+	r.Product = into(r.Product, r.ToPool)
+	for i := range r.ByProducts {
+		r.ByProducts[i] = into(r.ByProducts[i], r.ToPool)
+	}
+	for _, b := range r.Branches {
+		for i := range b.Products {
+			b.Products[i] = into(b.Products[i], r.ToPool)
+		}
+	}
+	if q := r.MinReactantRatio; q != nil {
+		q.Numerator = into(q.Numerator, r.FromPool)
+		q.Denominator = into(q.Denominator, r.FromPool)
+	}
+	r.FromPool, r.ToPool = "", ""
+	return r
+}
+
+// PoolCounts returns the counts of the species in pool, keyed by species.
+func (p *Pond) PoolCounts(pool string) map[string]int {
+	counts := map[string]int{}
+	for key, count := range p.Molecules {
+		if species, in := SplitPoolKey(key); in == pool {
+			counts[species] += count
+		}
+	}
+	return counts
+}
+
+// SpeciesTotal returns the count of species summed over all pools.
+func (p *Pond) SpeciesTotal(species string) int {
+	total := 0
+	for key, count := range p.Molecules {
+		if s, _ := SplitPoolKey(key); s == species {
+			total += count
+		}
+	}
+	return total
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPoolKeys(t *testing.T) {
+	tests := []struct {
+		species, pool, key string
+	}{
+		{"E", "membrane", "E@membrane"},
+		{"E", BulkPool, "E"},
+		{"E", "", "E"},
+	}
+	for _, tt := range tests {
+		if got := PoolKey(tt.species, tt.pool); got != tt.key {
+			t.Errorf("PoolKey(%q, %q) = %q, want %q", tt.species, tt.pool, got, tt.key)
+		}
+	}
+	for key, want := range map[string][2]string{"E@membrane": {"E", "membrane"}, "E": {"E", BulkPool}} {
+		if species, pool := SplitPoolKey(key); species != want[0] || pool != want[1] {
+			t.Errorf("SplitPoolKey(%q) = %q, %q; want %q, %q", key, species, pool, want[0], want[1])
+		}
+	}
+}
+
+func TestTransferBetweenPools(t *testing.T) {
+	cfg := &Config{
+		Seed:      1,
+		Molecules: map[string]int{"E@free": 100, "E@membrane": 0},
+		Reactions: []Reaction{{Reactants: []string{"E"}, Product: "E", FromPool: "free", ToPool: "membrane"}},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.NewPond()
+	for i := 0; i < 30; i++ {
+		p.Step()
+	}
+	if free, membrane := p.PoolCounts("free")["E"], p.PoolCounts("membrane")["E"]; free != 70 || membrane != 30 {
+		t.Errorf("free E = %d, membrane E = %d; want 70, 30", free, membrane)
+	}
+	if got := p.SpeciesTotal("E"); got != 100 {
+		t.Errorf("total E = %d, want 100 conserved", got)
+	}
+}
+
+func TestPooledWithoutPools(t *testing.T) {
+	r := Reaction{Reactants: []string{"A", "B"}, Product: "D"}
+	if got := r.Pooled(); !reflect.DeepEqual(got, r) {
+		t.Errorf("Pooled() = %+v, want %+v unchanged", got, r)
+	}
+
+	r = Reaction{Reactants: []string{"A", "X@other"}, Product: "D", Catalysts: []string{"E"}, FromPool: "in", ToPool: BulkPool}
+	want := Reaction{Reactants: []string{"A@in", "X@other"}, Product: "D", Catalysts: []string{"E@in"}}
+	if got := r.Pooled(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Pooled() = %+v, want %+v", got, want)
+	}
+	if r.Reactants[0] != "A" {
+		t.Errorf("Pooled changed the original reaction: %+v", r)
+	}
+}
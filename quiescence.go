@@ -0,0 +1,38 @@
+package main
+
+// --- Quiescence ---
+
+// quiescenceDetector notices when a whole frame's steps went by without a
+// single successful fire.
+type quiescenceDetector struct {
+	lastFires int
+	quiescent bool
+}
+
+// Observe takes the total fire count at the end of a frame and reports
+// whether the pond was quiescent during it.
+func (d *quiescenceDetector) Observe(fires int) bool {
+	d.quiescent = fires == d.lastFires
+	d.lastFires = fires
+	return d.quiescent
+}
+
+// Quiescent reports whether the last observed frame had no fires.
+func (d *quiescenceDetector) Quiescent() bool {
+	return d.quiescent
+}
+
+// TotalFires returns the number of successful fires so far.
+func (p *Pond) TotalFires() int {
+	total := 0
+	for _, n := range p.FireCounts {
+		total += n
+	}
+	return total
+}
+
+// canProgress reports whether stepping could change anything: some reaction
+// can fire, or held-back catalysts are still due to return.
+func (p *Pond) canProgress() bool {
+	return len(p.pending) > 0 || p.TotalPropensity() > 0
+}
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestQuiescenceDetector(t *testing.T) {
+	var d quiescenceDetector
+	for i, step := range []struct {
+		fires int
+		want  bool
+	}{
+		{5, false},  // Fires during the frame
+		{5, true},   // A frame of failed steps
+		{5, true},   // Still nothing
+		{6, false},  // One fire succeeded
+		{20, false}, // Back at full speed
+	} {
+		if got := d.Observe(step.fires); got != step.want || d.Quiescent() != step.want {
+			t.Errorf("frame %d: Observe(%d) = %t, want %t", i, step.fires, got, step.want)
+		}
+	}
+}
+
+func TestQuiescentGameResumesAfterInjection(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 0, "B": 0}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	g := newGameWithPond(p)
+	g.StepsPerTick = 10
+
+	for frame := 0; frame < 3; frame++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !g.idle.Quiescent() || g.TickCounter != 1 {
+		t.Fatalf("quiescent %t after %d ticks, want stepping paused after 1", g.idle.Quiescent(), g.TickCounter)
+	}
+
+	if _, _, err := p.Inject("A 5"); err != nil {
+		t.Fatal(err)
+	}
+	for frame := 0; frame < 2; frame++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.TickCounter != 3 || p.Molecules["B"] == 0 {
+		t.Errorf("after injection ran to tick %d with B = %d, want stepping resumed", g.TickCounter, p.Molecules["B"])
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "sort"
+
+// --- RAF Analysis ---
+
+// FindRAF returns the indices of the maximal RAF (reflexively autocatalytic
+// and food-generated) subset of the reactions, or an empty slice when there
+// is none. In a RAF every reaction is catalyzed and both its reactants and
+// its catalysts can be made from the food set by reactions of the subset.
+// As reactions here need all of their catalysts, every catalyst must be
+// producible, not just one.
+//
+// It uses the standard reduction: starting from all reactions, repeatedly
+// compute the closure of the food set under the remaining reactions and
+// drop every reaction that is uncatalyzed or whose reactants or catalysts
+// fall outside it, until nothing more is dropped.
+func (p *Pond) FindRAF(foodSet []string) []int {
+	kept := make([]int, 0, len(p.Reactions))
+	for i, r := range p.Reactions {
+		if len(r.AllCatalysts()) > 0 {
+			kept = append(kept, i)
+		}
+	}
+
+	for {
+		closure := p.foodClosure(foodSet, kept)
+		next := make([]int, 0, len(kept))
+		for _, i := range kept {
+			r := p.Reactions[i]
+			if allIn(closure, r.Reactants) && allIn(closure, r.AllCatalysts()) {
+				next = append(next, i)
+			}
+		}
+		if len(next) == len(kept) {
+			sort.Ints(next)
+			return next
+		}
+		kept = next
+	}
+}
+
+// foodClosure returns the species that can be made from the food set using
+// only the given reactions, ignoring their catalysts.
+func (p *Pond) foodClosure(foodSet []string, reactions []int) map[string]bool {
+	closure := make(map[string]bool, len(foodSet))
+	for _, name := range foodSet {
+		closure[name] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, i := range reactions {
+			r := p.Reactions[i]
+			if !allIn(closure, r.Reactants) {
+				continue
+			}
+			for _, product := range r.AllProducts() {
+				if !closure[product] {
+					closure[product] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return closure
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindRAF(t *testing.T) {
+	tests := []struct {
+		name      string
+		food      []string
+		reactions []Reaction
+		want      []int
+	}{
+		{
+			name: "mutually catalytic pair",
+			food: []string{"a", "b"},
+			reactions: []Reaction{
+				{Reactants: []string{"a", "b"}, Product: "c", Catalysts: []string{"d"}},
+				{Reactants: []string{"c", "a"}, Product: "d", Catalysts: []string{"c"}},
+				{Reactants: []string{"x"}, Product: "y", Catalysts: []string{"c"}}, // x is not food-generated
+				{Reactants: []string{"a"}, Product: "e"},                           // Uncatalyzed
+			},
+			want: []int{0, 1},
+		},
+		{
+			name: "legacy catalyst field",
+			food: []string{"a"},
+			reactions: []Reaction{
+				{Reactants: []string{"a"}, Product: "b", Catalyst: "b"},
+			},
+			want: []int{0},
+		},
+		{
+			name: "catalyst never made",
+			food: []string{"a"},
+			reactions: []Reaction{
+				{Reactants: []string{"a"}, Product: "c", Catalysts: []string{"d"}},
+			},
+		},
+		{
+			name: "removal cascades",
+			food: []string{"a"},
+			reactions: []Reaction{
+				{Reactants: []string{"a"}, Product: "b", Catalysts: []string{"c"}},
+				{Reactants: []string{"b"}, Product: "c", Catalysts: []string{"z"}},
+			},
+		},
+		{
+			name: "every catalyst needed",
+			food: []string{"a"},
+			reactions: []Reaction{
+				{Reactants: []string{"a"}, Product: "b", Catalysts: []string{"b", "q"}},
+			},
+		},
+		{
+			name: "no catalysis",
+			food: []string{"a"},
+			reactions: []Reaction{
+				{Reactants: []string{"a"}, Product: "b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPond(1, map[string]int{}, tt.reactions...)
+			got := p.FindRAF(tt.food)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("FindRAF(%v) = %#v, want %v", tt.food, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRAFDefaultNetwork(t *testing.T) {
+	p := NewPondWithSeed(1)
+
+	// E's catalyzed route needs D, which only the uncatalyzed A + B -> D makes
+	if raf := p.FindRAF([]string{"A", "B", "C"}); len(raf) != 0 {
+		t.Errorf("FindRAF(A, B, C) = %v, want no RAF", raf)
+	}
+	if raf := p.FindRAF([]string{"A", "B", "C", "D"}); !slices.Equal(raf, []int{2}) {
+		t.Errorf("FindRAF(A, B, C, D) = %v, want [2]", raf)
+	}
+}
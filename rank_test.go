@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankByCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   []string
+	}{
+		{"descending", map[string]int{"A": 5, "B": 500, "C": 50}, []string{"B", "C", "A"}},
+		{"ties by name", map[string]int{"D": 7, "B": 7, "A": 1, "C": 7}, []string{"B", "C", "D", "A"}},
+		{"zeros last", map[string]int{"E": 0, "A": 0, "X": 3}, []string{"X", "A", "E"}},
+		{"empty", map[string]int{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankByCount(tt.counts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankByCount(%v) = %v, want %v", tt.counts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableSpeciesRanking(t *testing.T) {
+	g := newGameWithPond(testPond(1, map[string]int{"A": 1, "B": 30, "C": 20}))
+	if got, want := g.tableSpecies(), []string{"A", "B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("alphabetical table = %v, want %v", got, want)
+	}
+	g.RankByCount = true
+	if got, want := g.tableSpecies(), []string{"B", "C", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked table = %v, want %v", got, want)
+	}
+	g.Setup = true // Rows keep still while counts are edited
+	if got, want := g.tableSpecies(), []string{"A", "B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked table during setup = %v, want %v", got, want)
+	}
+}
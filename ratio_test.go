@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestReactantRatioMet(t *testing.T) {
+	q := ReactantRatio{Numerator: "A", Denominator: "B", Ratio: 2}
+	tests := []struct {
+		num, den float64
+		want     bool
+	}{
+		{20, 10, true},
+		{30, 10, true},
+		{19, 10, false},
+		{5, 0, false}, // A zero denominator blocks
+		{0, 0, false},
+	}
+	for _, tt := range tests {
+		if got := q.Met(tt.num, tt.den); got != tt.want {
+			t.Errorf("Met(%v, %v) = %t, want %t", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+// ratioPond has A + B -> C requiring A:B >= 2:1, plus an unconditional X -> Y
+// so there is always something to select.
+func ratioPond(a, b int) *Pond {
+	return testPond(1, map[string]int{"A": a, "B": b, "C": 0, "X": 100000, "Y": 0},
+		Reaction{Reactants: []string{"A", "B"}, Product: "C",
+			MinReactantRatio: &ReactantRatio{Numerator: "A", Denominator: "B", Ratio: 2}},
+		Reaction{Reactants: []string{"X"}, Product: "Y"},
+	)
+}
+
+func TestMinReactantRatioStep(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  int
+		fires bool
+	}{
+		{"ratio unmet", 10, 10, false},
+		{"zero denominator", 10, 0, false},
+		{"ratio met", 100, 10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ratioPond(tt.a, tt.b)
+			if got := p.Propensity(0) > 0; got != tt.fires {
+				t.Errorf("Propensity(0) = %v, want it positive: %t", p.Propensity(0), tt.fires)
+			}
+			for _, gillespie := range []bool{false, true} {
+				p := ratioPond(tt.a, tt.b)
+				p.Gillespie = gillespie
+				p.Run(200)
+				if got := p.FireCounts[0] > 0; got != tt.fires {
+					t.Errorf("gillespie %t: reaction fired %d times, want firing: %t", gillespie, p.FireCounts[0], tt.fires)
+				}
+			}
+		})
+	}
+}
+
+func TestMinReactantRatioHybrid(t *testing.T) {
+	p := ratioPond(10, 10)
+	p.ODEThreshold = 1000 // A, B and C are rare, so the reaction is stochastic
+	p.Hybrid = true
+	for i := 0; i < 20; i++ {
+		p.Run(100)
+	}
+	if p.Molecules["C"] != 0 || p.Molecules["A"] != 10 {
+		t.Errorf("hybrid mode fired a reaction whose ratio is unmet: %v", p.Molecules)
+	}
+	if p.Molecules["Y"] == 0 {
+		t.Error("nothing ran in hybrid mode")
+	}
+}
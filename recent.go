@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Recent Events Panel ---
+
+// RecentEventsCapacity is the number of fires the recent events panel keeps.
+const RecentEventsCapacity = 15
+
+// recentEvent is one fire kept for the panel; its text is built when drawn.
+type recentEvent struct {
+	Tick int
+	fireEvent
+}
+
+// eventRing keeps the latest events in a fixed-size ring, discarding the
+// oldest once full.
+type eventRing struct {
+	entries []recentEvent
+	next    int // Slot the next event is written to
+	full    bool
+}
+
+// newEventRing creates an empty ring holding up to capacity events (minimum 1).
+func newEventRing(capacity int) *eventRing {
+	return &eventRing{entries: make([]recentEvent, max(capacity, 1))}
+}
+
+// Add records an event, overwriting the oldest when the ring is full.
+func (r *eventRing) Add(e recentEvent) {
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Newest returns the stored events, newest first.
+func (r *eventRing) Newest() []recentEvent {
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	out := make([]recentEvent, n)
+	for i := range out {
+		out[i] = r.entries[(r.next-1-i+len(r.entries))%len(r.entries)]
+	}
+	return out
+}
+
+// Placement of the recent events panel, left of the network statistics panel.
+const (
+	recentPanelWidth = 330
+	recentPanelX     = netStatsPanelX - recentPanelWidth - 10
+	recentPanelY     = netStatsPanelY
+	recentLineStep   = 14
+)
+
+// toggleRecentFreeze freezes the recent events panel on its current
+// contents, or resumes following new events.
+func (g *Game) toggleRecentFreeze() {
+	if g.recentFrozen == nil {
+		g.recentFrozen = g.Recent.Newest()
+	} else {
+		g.recentFrozen = nil
+	}
+}
+
+// drawRecentEvents draws the latest fires, newest first, or the frozen
+// snapshot while scrolling is frozen.
+func (g *Game) drawRecentEvents(screen *ebiten.Image) {
+	events := g.recentFrozen
+	title := "Recent events"
+	if events == nil {
+		events = g.Recent.Newest()
+	} else {
+		title += " (frozen)"
+	}
+
+	height := float32(24 + recentLineStep*RecentEventsCapacity)
+	vector.FillRect(screen, recentPanelX, recentPanelY, recentPanelWidth, height, color.RGBA{20, 20, 40, 230}, false)
+	vector.StrokeRect(screen, recentPanelX, recentPanelY, recentPanelWidth, height, 1, g.Theme.Header, false)
+
+	y := recentPanelY + 15
+	text.Draw(screen, title, basicfont.Face7x13, recentPanelX+10, y, g.Theme.Header)
+	for _, e := range events {
+		y += recentLineStep
+		line := fmt.Sprintf("%6d  %s", e.Tick, g.Pond.describeFire(e.fireEvent))
+		text.Draw(screen, line, basicfont.Face7x13, recentPanelX+10, y, color.White)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+// ticksOf returns the ticks of events, in order.
+func ticksOf(events []recentEvent) []int {
+	ticks := make([]int, len(events))
+	for i, e := range events {
+		ticks[i] = e.Tick
+	}
+	return ticks
+}
+
+func TestEventRingKeepsLatest(t *testing.T) {
+	r := newEventRing(3)
+	if got := r.Newest(); len(got) != 0 {
+		t.Fatalf("empty ring holds %v", ticksOf(got))
+	}
+	tests := []struct {
+		add  int
+		want []int
+	}{
+		{1, []int{1}},
+		{2, []int{2, 1}},
+		{3, []int{3, 2, 1}},
+		{4, []int{4, 3, 2}}, // The oldest is discarded
+		{5, []int{5, 4, 3}},
+		{6, []int{6, 5, 4}},
+		{7, []int{7, 6, 5}},
+	}
+	for _, tt := range tests {
+		r.Add(recentEvent{Tick: tt.add})
+		got := ticksOf(r.Newest())
+		if len(got) != len(tt.want) {
+			t.Fatalf("after adding %d: %v, want %v", tt.add, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("after adding %d: %v, want %v", tt.add, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestRecentEventsFreeze(t *testing.T) {
+	g := NewGame()
+	g.advance(g.StepsPerTick)
+	if len(g.Recent.Newest()) == 0 {
+		t.Fatal("no recent events after a tick")
+	}
+
+	g.toggleRecentFreeze()
+	frozen := ticksOf(g.recentFrozen)
+	for i := 0; i < 3; i++ {
+		g.advance(g.StepsPerTick)
+	}
+	if got := ticksOf(g.recentFrozen); len(got) != len(frozen) || got[0] != frozen[0] {
+		t.Errorf("frozen panel changed from %v to %v", frozen, got)
+	}
+	if newest := g.Recent.Newest()[0].Tick; newest != g.TickCounter {
+		t.Errorf("newest event at tick %d while frozen, want %d: the ring keeps recording", newest, g.TickCounter)
+	}
+
+	g.toggleRecentFreeze()
+	if g.recentFrozen != nil {
+		t.Error("panel still frozen after toggling back")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// --- Render Quality ---
+
+// renderer draws the primitives of the count bars and time-series lines,
+// anti-aliased or not. Anti-aliased paths are slower but better suited to
+// screenshots.
+type renderer struct {
+	antiAlias bool
+}
+
+func (r renderer) fillRect(dst *ebiten.Image, x, y, width, height float32, clr color.Color) {
+	vector.FillRect(dst, x, y, width, height, clr, r.antiAlias)
+}
+
+func (r renderer) strokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, clr color.Color) {
+	vector.StrokeLine(dst, x0, y0, x1, y1, 1, clr, r.antiAlias)
+}
+
+// renderer returns the primitives matching the game's render quality.
+func (g *Game) renderer() renderer {
+	return renderer{antiAlias: g.AntiAlias}
+}
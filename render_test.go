@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestRenderEverySamplesHistory(t *testing.T) {
+	g := NewGame()
+	g.StepsPerTick = 10
+	g.RenderEvery = 10
+	observed := 0
+	g.Pond.AddObserver(func(int, *Pond) { observed++ })
+
+	for frame := 0; frame < 5; frame++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.TickCounter != 50 || observed != 50 {
+		t.Errorf("ran %d ticks with %d observed, want 50 of each", g.TickCounter, observed)
+	}
+	points := g.History.Points()
+	if len(points) != 5 {
+		t.Fatalf("history holds %d samples, want 5", len(points))
+	}
+	for i, point := range points {
+		if want := 10 * (i + 1); point.Tick != want {
+			t.Errorf("sample %d at tick %d, want %d", i, point.Tick, want)
+		}
+	}
+}
+
+func TestRendererFollowsAntiAlias(t *testing.T) {
+	g := NewGame()
+	if g.renderer().antiAlias {
+		t.Error("default renderer is anti-aliased")
+	}
+	g.AntiAlias = true
+	if !g.renderer().antiAlias {
+		t.Error("renderer with AntiAlias is not anti-aliased")
+	}
+	g.AntiAlias = false
+	if g.renderer().antiAlias {
+		t.Error("renderer after turning AntiAlias off is still anti-aliased")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// --- Competing Replicators ---
+
+// CompetitionConfig returns a chemistry in which replicators E1..En compete
+// for the shared precursor D. Food A becomes D (A -> D), replicator i copies
+// itself from D (D -> Ei, catalyzed by Ei) at rates[i], and every replicator
+// decays back to food at the same rate (Ei -> A), so the total mass is
+// constant. All replicators start from the same count, so differences in
+// outcome come from the autocatalytic rates alone.
+//
+// Competition needs Gillespie mode, where a replicator's propensity grows
+// with its count; with plain rate-weighted selection a reaction's firing
+// does not depend on how many catalyst molecules there are.
+func CompetitionConfig(rates []float64) *Config {
+	cfg := &Config{
+		StepsPerTick:       DefaultStepsPerTick,
+		EmergenceThreshold: DefaultEmergenceThreshold,
+		Molecules:          map[string]int{"A": 1000, "D": 0},
+		Reactions: []Reaction{
+			{Reactants: []string{"A"}, Product: "D"},
+		},
+	}
+	for i, rate := range rates {
+		e := fmt.Sprintf("E%d", i+1)
+		cfg.Molecules[e] = 10
+		cfg.Reactions = append(cfg.Reactions,
+			Reaction{Reactants: []string{"D"}, Product: e, Catalysts: []string{e}, Rate: rate},
+			Reaction{Reactants: []string{e}, Product: "A"},
+		)
+	}
+	return cfg
+}
+
+// Replicators returns the species that catalyze their own production, sorted.
+func (p *Pond) Replicators() []string {
+	seen := make(map[string]bool)
+	for _, r := range p.Reactions {
+		for _, c := range r.AllCatalysts() {
+			for _, product := range r.AllProducts() {
+				if c == product {
+					seen[c] = true
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LeadingReplicator returns the most abundant replicator and its share of
+// all replicator molecules (0 when none are present). Ties go to the name
+// that sorts first.
+func (p *Pond) LeadingReplicator() (species string, share float64) {
+	total, best := 0, -1
+	for _, name := range p.Replicators() {
+		count := p.Molecules[name]
+		total += count
+		if count > best {
+			species, best = name, count
+		}
+	}
+	if total > 0 {
+		share = float64(best) / float64(total)
+	}
+	return species, share
+}
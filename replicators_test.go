@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestFasterReplicatorDominates(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		cfg := CompetitionConfig([]float64{1, 2})
+		cfg.Seed = seed
+		p := cfg.NewPond()
+		p.Gillespie = true
+		p.Run(300000)
+
+		leader, share := p.LeadingReplicator()
+		if leader != "E2" || share < 0.9 {
+			t.Errorf("seed %d: %s leads with %.2f of replicators (%v), want E2 above 0.9", seed, leader, share, p.Molecules)
+		}
+	}
+}
+
+func TestReplicators(t *testing.T) {
+	p := CompetitionConfig([]float64{1, 1, 1}).NewPond()
+	if got := p.Replicators(); len(got) != 3 || got[0] != "E1" || got[2] != "E3" {
+		t.Errorf("Replicators() = %v, want [E1 E2 E3]", got)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import "math/rand"
+
+// --- Cloneable Random Source ---
+
+// splitMix64 is a small rand.Source64 whose whole state is one word, so it
+// can be copied by value.
+type splitMix64 struct {
+	state uint64
+}
+
+func (s *splitMix64) Seed(seed int64) { s.state = uint64(seed) }
+
+func (s *splitMix64) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *splitMix64) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// cloneableRand is a *rand.Rand whose state can be duplicated, so callers
+// can look ahead at future draws without consuming them.
+type cloneableRand struct {
+	*rand.Rand
+	src *splitMix64
+}
+
+// newCloneableRand returns a random source seeded with seed.
+func newCloneableRand(seed int64) *cloneableRand {
+	src := &splitMix64{state: uint64(seed)}
+	return &cloneableRand{Rand: rand.New(src), src: src}
+}
+
+// Clone returns an independent source that will produce the same draws as r.
+func (r *cloneableRand) Clone() *cloneableRand {
+	src := *r.src
+	return &cloneableRand{Rand: rand.New(&src), src: &src}
+}
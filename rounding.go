@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// --- Rounding Fractional Amounts ---
+
+// RoundingMode decides how fractional molecule amounts (from fractions,
+// concentrations and the like) become whole counts.
+type RoundingMode int
+
+const (
+	RoundNearest    RoundingMode = iota // Nearest integer, halves away from zero (the default)
+	RoundFloor                          // Always down; systematically loses the fractional part
+	RoundStochastic                     // Up with probability equal to the fractional part; unbiased in expectation
+)
+
+var roundingModeNames = [...]string{"nearest", "floor", "stochastic"}
+
+func (m RoundingMode) String() string {
+	if m < 0 || int(m) >= len(roundingModeNames) {
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+	return roundingModeNames[m]
+}
+
+// ParseRoundingMode accepts "nearest", "floor" or "stochastic".
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	for i, name := range roundingModeNames {
+		if s == name {
+			return RoundingMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rounding mode %q (want nearest, floor or stochastic)", s)
+}
+
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	mode, err := ParseRoundingMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// roundCount converts x to a whole number of molecules using mode. Only
+// stochastic rounding draws from rng.
+func roundCount(x float64, mode RoundingMode, rng *rand.Rand) int {
+	switch mode {
+	case RoundFloor:
+		return int(math.Floor(x))
+	case RoundStochastic:
+		whole := math.Floor(x)
+		if rng.Float64() < x-whole {
+			whole++
+		}
+		return int(whole)
+	default:
+		return int(math.Round(x))
+	}
+}
+
+// round converts a fractional amount to a count using the pond's rounding mode.
+func (p *Pond) round(x float64) int {
+	return roundCount(x, p.Rounding, p.rng.Rand)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStochasticRoundingIsUnbiased(t *testing.T) {
+	const trials = 100000
+	rng := rand.New(rand.NewSource(1))
+	ones := 0
+	for i := 0; i < trials; i++ {
+		switch n := roundCount(0.3, RoundStochastic, rng); n {
+		case 0:
+		case 1:
+			ones++
+		default:
+			t.Fatalf("stochastic rounding of 0.3 gave %d", n)
+		}
+	}
+	if share := float64(ones) / trials; math.Abs(share-0.3) > 0.01 {
+		t.Errorf("0.3 rounded up %.3f of the time, want 0.3 ± 0.01", share)
+	}
+}
+
+func TestDeterministicRounding(t *testing.T) {
+	tests := []struct {
+		x    float64
+		mode RoundingMode
+		want int
+	}{
+		{2.7, RoundFloor, 2},
+		{2.2, RoundFloor, 2},
+		{2.5, RoundNearest, 3},
+		{2.4, RoundNearest, 2},
+		{3, RoundStochastic, 3},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		if got := roundCount(tt.x, tt.mode, rng); got != tt.want {
+			t.Errorf("roundCount(%v, %v) = %d, want %d", tt.x, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for _, mode := range []RoundingMode{RoundNearest, RoundFloor, RoundStochastic} {
+		text, err := mode.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed RoundingMode
+		if err := parsed.UnmarshalText(text); err != nil || parsed != mode {
+			t.Errorf("round trip of %v gave %v, %v", mode, parsed, err)
+		}
+	}
+	if _, err := ParseRoundingMode("ceil"); err == nil {
+		t.Error("ParseRoundingMode(ceil) succeeded, want an error")
+	}
+}
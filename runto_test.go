@@ -0,0 +1,42 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestPondRunToIsReproducible(t *testing.T) {
+	first, second := NewPondWithSeed(9), NewPondWithSeed(9)
+	first.RunTo(1000)
+	second.RunTo(1000)
+	if first.Tick != 1000 || first.StepCount != 1000*DefaultStepsPerTick {
+		t.Errorf("at tick %d after %d steps, want tick 1000 after %d", first.Tick, first.StepCount, 1000*DefaultStepsPerTick)
+	}
+	if !maps.Equal(first.Molecules, second.Molecules) || !slices.Equal(first.FireCounts, second.FireCounts) {
+		t.Errorf("identically seeded ponds differ at tick 1000: %v vs %v", first.Molecules, second.Molecules)
+	}
+
+	first.RunTo(500) // Already past it
+	if first.Tick != 1000 {
+		t.Errorf("RunTo an earlier tick moved the pond to tick %d", first.Tick)
+	}
+}
+
+func TestPondRunToMatchesGame(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Seed = 4
+	cfg.StepsPerTick = 30
+	cfg.Reactions[3].HalfLife = 20 // Per-tick work must line up too
+
+	p := cfg.NewPond()
+	g := cfg.NewGame()
+	p.RunTo(200)
+	g.RunTo(200)
+	if g.Pond.Tick != g.TickCounter {
+		t.Errorf("game at tick %d, its pond at %d", g.TickCounter, g.Pond.Tick)
+	}
+	if !maps.Equal(p.Molecules, g.Pond.Molecules) || p.StepCount != g.Pond.StepCount {
+		t.Errorf("pond %v after %d steps, game %v after %d", p.Molecules, p.StepCount, g.Pond.Molecules, g.Pond.StepCount)
+	}
+}
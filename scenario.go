@@ -0,0 +1,45 @@
+package main
+
+// --- Scenario Harness ---
+
+// ScenarioResult is the outcome of RunScenario.
+type ScenarioResult struct {
+	Seed          int64
+	Steps         int            // Simulation steps actually run
+	Ticks         int            // Ticks of StepsPerTick steps (the last may be shorter)
+	EmergenceTick int            // First tick after which emergence held, or -1 if never
+	FinalCounts   map[string]int // Molecule counts at the end of the run
+	FireCounts    []int          // Successful fires per reaction index
+}
+
+// Emerged reports whether emergence was reached during the run.
+func (r ScenarioResult) Emerged() bool {
+	return r.EmergenceTick >= 0
+}
+
+// RunScenario runs the chemistry in cfg with the given seed, without a
+// window, for at most maxSteps steps. It stops at the first tick on which
+// emergence is reached, so the result can be used to assert when (and
+// whether) a chemistry emerges. The same cfg and seed always give the same
+// result. cfg itself is not modified.
+func RunScenario(cfg Config, seed int64, maxSteps int) ScenarioResult {
+	cfg.Seed = seed
+	g := cfg.NewGame()
+
+	res := ScenarioResult{Seed: seed, EmergenceTick: -1}
+	for res.Steps < maxSteps && g.StepsPerTick > 0 {
+		n := min(g.StepsPerTick, maxSteps-res.Steps)
+		g.advance(n)
+		res.Steps += n
+		if g.Emerged() {
+			res.EmergenceTick = g.TickCounter
+			break
+		}
+	}
+
+	res.Ticks = g.TickCounter
+	res.FinalCounts = g.Pond.Molecules
+	res.FireCounts = make([]int, len(g.Pond.Reactions))
+	copy(res.FireCounts, g.Pond.FireCounts)
+	return res
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestRunScenarioDefaultChemistry(t *testing.T) {
+	cfg := *DefaultConfig()
+	cfg.EmergenceThreshold = 20 // Reachable with the default food supply
+
+	first := RunScenario(cfg, 1, 200000)
+	if !first.Emerged() {
+		t.Fatalf("no emergence within %d steps: %v", first.Steps, first.FinalCounts)
+	}
+	if first.EmergenceTick != 15 {
+		t.Errorf("emergence at tick %d, want 15", first.EmergenceTick)
+	}
+	if first.FinalCounts["E"] <= cfg.EmergenceThreshold {
+		t.Errorf("final E = %d, not past the threshold %d", first.FinalCounts["E"], cfg.EmergenceThreshold)
+	}
+
+	second := RunScenario(cfg, 1, 200000)
+	if second.EmergenceTick != first.EmergenceTick || second.Steps != first.Steps ||
+		!maps.Equal(second.FinalCounts, first.FinalCounts) || !slices.Equal(second.FireCounts, first.FireCounts) {
+		t.Errorf("repeated scenario differs: %+v vs %+v", second, first)
+	}
+}
+
+func TestRunScenarioWithoutEmergence(t *testing.T) {
+	res := RunScenario(*DefaultConfig(), 1, 5000) // The default threshold is out of reach
+	if res.Emerged() || res.EmergenceTick != -1 {
+		t.Errorf("emergence reported at tick %d", res.EmergenceTick)
+	}
+	if res.Steps != 5000 || res.Ticks != 5000/DefaultStepsPerTick {
+		t.Errorf("ran %d steps in %d ticks, want 5000 in %d", res.Steps, res.Ticks, 5000/DefaultStepsPerTick)
+	}
+}
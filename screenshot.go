@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// --- Emergence Screenshot ---
+
+// emergenceTrigger signals the first frame on which emergence is observed,
+// and never again for the rest of the run.
+type emergenceTrigger struct {
+	fired bool
+}
+
+// Observe reports whether this observation is the one that should trigger:
+// true exactly once, the first time emerged is true.
+func (t *emergenceTrigger) Observe(emerged bool) bool {
+	if !emerged || t.fired {
+		return false
+	}
+	t.fired = true
+	return true
+}
+
+// Emerged reports whether the emergence condition holds, by default whether
+// the CAS product has passed the emergence threshold.
+func (g *Game) Emerged() bool {
+	if g.Emergence != nil {
+		return g.Emergence.Holds(g.Pond)
+	}
+	return g.Pond.Molecules["E"] > g.EmergenceThreshold
+}
+
+// captureEmergence saves the finished frame as a PNG the first time
+// emergence is seen, when screenshots are enabled.
+func (g *Game) captureEmergence(screen *ebiten.Image) {
+	if !g.ScreenshotOnEmergence || !g.emergenceShot.Observe(g.Emerged()) {
+		return
+	}
+	path := fmt.Sprintf("emergence_tick_%d.png", g.TickCounter)
+	if err := saveScreenshot(screen, path); err != nil {
+		logf(LevelError, "emergence screenshot: %v", err)
+		return
+	}
+	logf(LevelInfo, "emergence screenshot saved to %s", path)
+}
+
+// saveScreenshot reads the image's pixels back and writes them to path as a PNG.
+func saveScreenshot(img *ebiten.Image, path string) error {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	img.ReadPixels(rgba.Pix)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, rgba); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
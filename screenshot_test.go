@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestEmergenceTriggerFiresOnce(t *testing.T) {
+	// Emergence is reached at index 3, lost, then reached again
+	observations := []bool{false, false, false, true, true, false, true, true}
+	var trigger emergenceTrigger
+	fired := []int{}
+	for i, emerged := range observations {
+		if trigger.Observe(emerged) {
+			fired = append(fired, i)
+		}
+	}
+	if len(fired) != 1 || fired[0] != 3 {
+		t.Errorf("trigger fired at %v, want only at 3", fired)
+	}
+}
+
+func TestEmergenceTriggerAtTick(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(1))
+	g.EmergenceThreshold = 10
+	var trigger emergenceTrigger
+	firedAt := []int{}
+	for g.TickCounter < 20 {
+		g.advance(1)
+		if g.TickCounter == 5 {
+			g.Pond.Molecules["E"] = 11
+		}
+		if trigger.Observe(g.Emerged()) {
+			firedAt = append(firedAt, g.TickCounter)
+		}
+	}
+	if len(firedAt) != 1 || firedAt[0] != 5 {
+		t.Errorf("trigger fired at ticks %v, want only at 5", firedAt)
+	}
+}
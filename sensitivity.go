@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// --- Rate Sensitivity ---
+
+// SensitivityDelta is the relative change applied to each rate, in each direction.
+const SensitivityDelta = 0.1
+
+// RateSensitivity is how the emergence tick responds to one reaction's rate.
+type RateSensitivity struct {
+	Reaction int     // 0-based reaction index
+	Rate     float64 // Unperturbed effective rate
+	Down     float64 // Mean emergence tick with the rate lowered by SensitivityDelta
+	Up       float64 // Mean emergence tick with the rate raised by SensitivityDelta
+}
+
+// Change returns the shift in mean emergence tick from the lowered to the
+// raised rate; negative means a faster reaction brings emergence sooner.
+func (s RateSensitivity) Change() float64 {
+	return s.Up - s.Down
+}
+
+// SensitivityAnalysis perturbs each reaction's rate by ±SensitivityDelta and
+// measures the mean emergence tick over trials scenarios seeded cfg.Seed,
+// cfg.Seed+1, ... Every perturbation uses the same seeds, so differences come
+// from the rate rather than the noise. A run that never emerges counts as
+// emerging on its last tick. It returns the baseline mean emergence tick and
+// the sensitivities, most sensitive (largest |Change|) first.
+func SensitivityAnalysis(cfg *Config, trials, maxSteps int) (float64, []RateSensitivity) {
+	baseline := meanEmergenceTick(*cfg, trials, maxSteps)
+	sens := make([]RateSensitivity, len(cfg.Reactions))
+	for i, r := range cfg.Reactions {
+		rate := r.Rate
+		if rate <= 0 {
+			rate = 1 // As EffectiveRate treats an unset rate
+		}
+		sens[i] = RateSensitivity{
+			Reaction: i,
+			Rate:     rate,
+			Down:     meanEmergenceTick(withRate(*cfg, i, rate*(1-SensitivityDelta)), trials, maxSteps),
+			Up:       meanEmergenceTick(withRate(*cfg, i, rate*(1+SensitivityDelta)), trials, maxSteps),
+		}
+	}
+	sort.SliceStable(sens, func(a, b int) bool {
+		return math.Abs(sens[a].Change()) > math.Abs(sens[b].Change())
+	})
+	return baseline, sens
+}
+
+// withRate returns a copy of cfg with reaction i's rate replaced.
+func withRate(cfg Config, i int, rate float64) Config {
+	cfg.Reactions = append([]Reaction(nil), cfg.Reactions...)
+	cfg.Reactions[i].Rate = rate
+	return cfg
+}
+
+// meanEmergenceTick runs trials scenarios and averages their emergence ticks,
+// counting a run that never emerges as emerging on its last tick.
+func meanEmergenceTick(cfg Config, trials, maxSteps int) float64 {
+	total := 0
+	for t := 0; t < trials; t++ {
+		res := RunScenario(cfg, cfg.Seed+int64(t), maxSteps)
+		if res.Emerged() {
+			total += res.EmergenceTick
+		} else {
+			total += res.Ticks
+		}
+	}
+	return float64(total) / float64(max(trials, 1))
+}
+
+// FormatSensitivity renders the ranked sensitivities as a table.
+func FormatSensitivity(baseline float64, sens []RateSensitivity) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Baseline mean emergence tick: %.1f\n", baseline)
+	pct := 100 * SensitivityDelta
+	fmt.Fprintf(&b, "%-4s %-8s %-8s %10s %10s %10s\n", "rank", "reaction", "rate",
+		fmt.Sprintf("-%g%%", pct), fmt.Sprintf("+%g%%", pct), "change")
+	for rank, s := range sens {
+		fmt.Fprintf(&b, "%-4d R%-7d %-8.4g %10.1f %10.1f %+10.1f\n", rank+1, s.Reaction+1, s.Rate, s.Down, s.Up, s.Change())
+	}
+	return b.String()
+}
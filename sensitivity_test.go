@@ -0,0 +1,34 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSensitivityRanksAutocatalysis(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EmergenceThreshold = 20
+	cfg.Molecules["X"] = 100
+	cfg.Reactions = append(cfg.Reactions, Reaction{Reactants: []string{"X"}, Product: "Y"}) // Unrelated to E
+	const autocatalytic, unrelated = 2, 4
+
+	baseline, sens := SensitivityAnalysis(cfg, 5, 200000)
+	if baseline <= 0 {
+		t.Fatalf("baseline emergence tick %v", baseline)
+	}
+	change := map[int]float64{}
+	for _, s := range sens {
+		change[s.Reaction] = math.Abs(s.Change())
+	}
+	if len(change) != len(cfg.Reactions) {
+		t.Fatalf("%d sensitivities for %d reactions", len(change), len(cfg.Reactions))
+	}
+	if change[autocatalytic] <= change[unrelated] {
+		t.Errorf("autocatalytic |change| %.2f not above unrelated %.2f: %+v", change[autocatalytic], change[unrelated], sens)
+	}
+	for i := 1; i < len(sens); i++ {
+		if math.Abs(sens[i].Change()) > math.Abs(sens[i-1].Change()) {
+			t.Errorf("rank %d (%.2f) above rank %d (%.2f)", i+1, sens[i].Change(), i, sens[i-1].Change())
+		}
+	}
+}
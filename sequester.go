@@ -0,0 +1,57 @@
+package main
+
+import "sort"
+
+// --- Sequestered Catalysts ---
+
+// sequestered is a catalyst unit held back by a reaction until its release step.
+type sequestered struct {
+	Due      int    // StepCount at which the unit is returned
+	Species  string // Catalyst species
+	Reaction int    // Index of the reaction that took it
+}
+
+// sequester removes one unit of each catalyst and schedules its return delay steps from now.
+func (p *Pond) sequester(catalysts []string, idx, delay int) {
+	due := p.StepCount + delay
+	for _, c := range catalysts {
+		p.Molecules[c]--
+		if p.Lineage != nil {
+			p.recordConsumed(c)
+		}
+
+		// Keep the queue ordered by due step; equal due steps stay first-in, first-out
+		at := sort.Search(len(p.pending), func(i int) bool { return p.pending[i].Due > due })
+		p.pending = append(p.pending, sequestered{})
+		copy(p.pending[at+1:], p.pending[at:])
+		p.pending[at] = sequestered{Due: due, Species: c, Reaction: idx}
+	}
+}
+
+// releaseSequestered returns every catalyst unit whose release step has arrived.
+func (p *Pond) releaseSequestered() {
+	n := 0
+	for n < len(p.pending) && p.pending[n].Due <= p.StepCount {
+		unit := p.pending[n]
+		p.Molecules[unit.Species]++
+		if p.Lineage != nil {
+			p.recordProduced(unit.Species, unit.Reaction)
+		}
+		if p.propsCached {
+			p.refreshPropensities(p.reactionIndex().bySpecies[unit.Species])
+		}
+		n++
+	}
+	p.pending = p.pending[n:]
+}
+
+// SequesteredCount returns how many units of a species are currently held back.
+func (p *Pond) SequesteredCount(species string) int {
+	n := 0
+	for _, unit := range p.pending {
+		if unit.Species == species {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestSequesteredCatalystReturnsAfterDelay(t *testing.T) {
+	const delay = 5
+	r := Reaction{Reactants: []string{"A"}, Product: "B", Catalysts: []string{"X"}, CatalystDelay: delay}
+	p := testPond(1, map[string]int{"A": 1, "B": 0, "X": 1}, r)
+
+	p.Step()
+	if p.Molecules["B"] != 1 {
+		t.Fatalf("reaction did not fire: B = %d", p.Molecules["B"])
+	}
+	fired := p.StepCount
+	for p.StepCount < fired+delay-1 {
+		if p.Molecules["X"] != 0 || p.SequesteredCount("X") != 1 {
+			t.Fatalf("step %d: X = %d with %d held back, want 0 with 1",
+				p.StepCount, p.Molecules["X"], p.SequesteredCount("X"))
+		}
+		p.Step()
+	}
+	if p.Molecules["X"] != 0 {
+		t.Fatalf("X returned early, at step %d", p.StepCount)
+	}
+
+	p.Step()
+	if p.Molecules["X"] != 1 || p.SequesteredCount("X") != 0 {
+		t.Errorf("step %d: X = %d with %d held back, want 1 with 0",
+			p.StepCount, p.Molecules["X"], p.SequesteredCount("X"))
+	}
+	if p.StepCount != fired+delay {
+		t.Errorf("X returned at step %d, want %d", p.StepCount, fired+delay)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// --- HTTP Status Server ---
+
+// StateResponse is the body served at /state.
+type StateResponse struct {
+	Tick      int            `json:"tick"`
+	Steps     int            `json:"steps"`
+	Counts    map[string]int `json:"counts"`
+	Emerged   bool           `json:"emerged"`
+	Threshold int            `json:"emergenceThreshold"`
+}
+
+// MetricsResponse is the JSON body served at /metrics.
+type MetricsResponse struct {
+	Tick       int     `json:"tick"`
+	FireCounts []int   `json:"fireCounts"` // Per reaction, in reaction order
+	TotalFires int     `json:"totalFires"`
+	Diversity  float64 `json:"diversity"` // Shannon diversity of the counts, see Pond.Diversity
+}
+
+// State captures the game's current counts and emergence status.
+func (g *Game) State() StateResponse {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	counts := make(map[string]int, len(g.Pond.Molecules))
+	for name, count := range g.Pond.Molecules {
+		counts[name] = count
+	}
+	return StateResponse{
+		Tick:      g.TickCounter,
+		Steps:     g.Pond.StepCount,
+		Counts:    counts,
+		Emerged:   g.Emerged(),
+		Threshold: g.EmergenceThreshold,
+	}
+}
+
+// Metrics captures the game's fire counts and diversity.
+func (g *Game) Metrics() MetricsResponse {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fires := make([]int, len(g.Pond.Reactions))
+	copy(fires, g.Pond.FireCounts)
+	return MetricsResponse{
+		Tick:       g.TickCounter,
+		FireCounts: fires,
+		TotalFires: g.Pond.TotalFires(),
+		Diversity:  g.Pond.Diversity(),
+	}
+}
+
+// Handler serves the game's /state as JSON and /metrics in the Prometheus
+// text format, or as JSON when the request accepts application/json. It is
+// safe to use while the simulation runs.
+func (g *Game) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, g.State())
+	})
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			writeJSON(w, g.Metrics())
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		io.WriteString(w, g.PrometheusMetrics())
+	})
+	return mux
+}
+
+// PrometheusMetrics renders the game's metrics in the Prometheus text
+// exposition format. Metric names are stable; species and reactions are
+// labels, listed in sorted and reaction order.
+func (g *Game) PrometheusMetrics() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var b strings.Builder
+	metric := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	metric("abiogenesis_tick", "gauge", "Ticks simulated so far.")
+	fmt.Fprintf(&b, "abiogenesis_tick %d\n", g.TickCounter)
+	metric("abiogenesis_species_count", "gauge", "Current molecule count per species.")
+	for _, name := range g.Pond.SpeciesNames() {
+		fmt.Fprintf(&b, "abiogenesis_species_count{species=%q} %d\n", name, g.Pond.Molecules[name])
+	}
+	metric("abiogenesis_reactions_fired_total", "counter", "Successful reaction fires so far.")
+	fmt.Fprintf(&b, "abiogenesis_reactions_fired_total %d\n", g.Pond.TotalFires())
+	metric("abiogenesis_reaction_fires_total", "counter", "Successful fires per reaction.")
+	for i := range g.Pond.Reactions {
+		fires := 0
+		if i < len(g.Pond.FireCounts) {
+			fires = g.Pond.FireCounts[i]
+		}
+		fmt.Fprintf(&b, "abiogenesis_reaction_fires_total{reaction=\"R%d\"} %d\n", i+1, fires)
+	}
+	metric("abiogenesis_diversity", "gauge", "Shannon diversity of the species counts, in nats.")
+	fmt.Fprintf(&b, "abiogenesis_diversity %g\n", g.Pond.Diversity())
+	metric("abiogenesis_emerged", "gauge", "1 once the CAS product has passed the emergence threshold.")
+	emerged := 0
+	if g.Emerged() {
+		emerged = 1
+	}
+	fmt.Fprintf(&b, "abiogenesis_emerged %d\n", emerged)
+	return b.String()
+}
+
+// writeJSON writes v as an indented JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// Serve starts serving Handler on addr (e.g. ":8080") in the background.
+// Close shuts the server down.
+func (g *Game) Serve(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	g.server = &http.Server{Handler: g.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := g.server.Serve(l); err != http.ErrServerClosed {
+			logf(LevelError, "http server: %v", err)
+		}
+	}()
+	logf(LevelInfo, "serving /state and /metrics on %s", l.Addr())
+	return nil
+}
+
+// shutdownServer stops the HTTP server, if one was started.
+func (g *Game) shutdownServer() error {
+	if g.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return g.server.Shutdown(ctx)
+}
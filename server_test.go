@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// getJSON fetches path from srv and decodes the JSON body into v.
+func getJSON(t *testing.T, srv *httptest.Server, path string, v any) {
+	t.Helper()
+	req, err := http.NewRequest("GET", srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: %s", path, resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("GET %s: Content-Type %q", path, ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("GET %s: invalid JSON: %v", path, err)
+	}
+}
+
+func TestStateEndpoint(t *testing.T) {
+	g := NewGame()
+	g.EmergenceThreshold = 1 // Emerged once E passes 1
+	g.RunTo(20)
+	srv := httptest.NewServer(g.Handler())
+	defer srv.Close()
+
+	var state StateResponse
+	getJSON(t, srv, "/state", &state)
+	if state.Tick != 20 || state.Steps != g.Pond.StepCount {
+		t.Errorf("state at tick %d after %d steps, want 20 after %d", state.Tick, state.Steps, g.Pond.StepCount)
+	}
+	if !maps.Equal(state.Counts, g.Pond.Molecules) {
+		t.Errorf("state counts %v, pond %v", state.Counts, g.Pond.Molecules)
+	}
+	if state.Emerged != g.Emerged() || state.Threshold != 1 {
+		t.Errorf("state emerged %t (threshold %d), game emerged %t", state.Emerged, state.Threshold, g.Emerged())
+	}
+
+	var metrics MetricsResponse
+	getJSON(t, srv, "/metrics", &metrics)
+	if metrics.TotalFires != g.Pond.TotalFires() || len(metrics.FireCounts) != len(g.Pond.Reactions) {
+		t.Errorf("metrics %+v, pond fired %d times", metrics, g.Pond.TotalFires())
+	}
+}
+
+func TestStateEndpointWhileRunning(t *testing.T) {
+	g := NewGame()
+	srv := httptest.NewServer(g.Handler())
+	defer srv.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			g.advance(g.StepsPerTick)
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		var state StateResponse
+		getJSON(t, srv, "/state", &state)
+		// Each response is taken between ticks, never halfway through one
+		if state.Steps != state.Tick*g.StepsPerTick {
+			t.Fatalf("state at tick %d after %d steps, want %d", state.Tick, state.Steps, state.Tick*g.StepsPerTick)
+		}
+	}
+	<-done
+}
+
+// parsePrometheus parses text exposition samples into series -> value,
+// checking that every sample follows a TYPE line for its metric.
+func parsePrometheus(t *testing.T, text string) map[string]float64 {
+	t.Helper()
+	samples := map[string]float64{}
+	typed := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			fields := strings.Fields(line)
+			if len(fields) != 4 || (fields[3] != "gauge" && fields[3] != "counter") {
+				t.Fatalf("malformed TYPE line %q", line)
+			}
+			typed[fields[2]] = true
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP ") {
+			continue
+		}
+		series, value, ok := strings.Cut(line, " ")
+		if !ok {
+			t.Fatalf("malformed sample %q", line)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			t.Fatalf("sample %q: %v", line, err)
+		}
+		name, _, _ := strings.Cut(series, "{")
+		if !typed[name] {
+			t.Fatalf("sample %q has no TYPE line", line)
+		}
+		samples[series] = v
+	}
+	return samples
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	g := NewGame()
+	g.RunTo(10)
+	srv := httptest.NewServer(g.Handler())
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type %q, want the Prometheus text format", ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := parsePrometheus(t, string(body))
+
+	for series, want := range map[string]float64{
+		`abiogenesis_species_count{species="E"}`:          float64(g.Pond.Molecules["E"]),
+		`abiogenesis_species_count{species="A"}`:          float64(g.Pond.Molecules["A"]),
+		`abiogenesis_reactions_fired_total`:               float64(g.Pond.TotalFires()),
+		`abiogenesis_reaction_fires_total{reaction="R3"}`: float64(g.Pond.FireCounts[2]),
+		`abiogenesis_tick`:                                10,
+		`abiogenesis_diversity`:                           g.Pond.Diversity(),
+	} {
+		got, ok := samples[series]
+		if !ok {
+			t.Errorf("no %s sample in\n%s", series, body)
+		} else if math.Abs(got-want) > 1e-9 { // Diversity sums in map order
+			t.Errorf("%s = %v, want %v", series, got, want)
+		}
+	}
+}
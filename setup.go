@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// --- Pre-run Setup ---
+
+// DefaultSetupStep is how many molecules one setup adjustment adds or
+// removes; holding Shift makes it ten times as many.
+const DefaultSetupStep = 10
+
+// adjustCount returns count changed by steps increments of stepSize (negative
+// steps decrement), never going below zero.
+func adjustCount(count, steps, stepSize int) int {
+	return max(count+steps*stepSize, 0)
+}
+
+// updateSetup handles the setup screen shown before the first step: the
+// arrow keys pick a species and change its count, the mouse wheel changes
+// the count of the row under the cursor, and Enter starts the run.
+func (g *Game) updateSetup() {
+	names := g.tableSpecies()
+	if len(names) == 0 || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.Setup = false
+		g.Pond.Status = fmt.Sprintf("Started with %d molecules", g.Pond.TotalPopulation())
+		return
+	}
+	g.setupRow = min(max(g.setupRow, 0), len(names)-1)
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		g.setupRow = (g.setupRow - 1 + len(names)) % len(names)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		g.setupRow = (g.setupRow + 1) % len(names)
+	}
+
+	stepSize := g.SetupStep
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		stepSize *= 10
+	}
+	steps := 0
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		steps++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		steps--
+	}
+	if steps != 0 {
+		name := names[g.setupRow]
+		g.Pond.Molecules[name] = adjustCount(g.Pond.Molecules[name], steps, stepSize)
+	}
+
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		if name, ok := g.speciesRowAt(ebiten.CursorPosition()); ok {
+			notches := 1
+			if wheel < 0 {
+				notches = -1
+			}
+			g.Pond.Molecules[name] = adjustCount(g.Pond.Molecules[name], notches, stepSize)
+		}
+	}
+}
+
+// setupHint is the status line shown while setting up.
+func (g *Game) setupHint() string {
+	return fmt.Sprintf("SETUP: Up/Down pick, Left/Right or wheel adjust by %d (Shift x10), Enter starts", g.SetupStep)
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestAdjustCount(t *testing.T) {
+	tests := []struct {
+		count, steps, stepSize, want int
+	}{
+		{0, 1, 10, 10},
+		{25, 1, 10, 35},
+		{25, -1, 10, 15},
+		{25, 2, 100, 225},
+		{5, -1, 10, 0}, // Clamped at zero
+		{0, -3, 10, 0},
+		{40, 0, 10, 40},
+	}
+	for _, tt := range tests {
+		if got := adjustCount(tt.count, tt.steps, tt.stepSize); got != tt.want {
+			t.Errorf("adjustCount(%d, %d, %d) = %d, want %d", tt.count, tt.steps, tt.stepSize, got, tt.want)
+		}
+	}
+}
+
+func TestSetupHoldsTheRun(t *testing.T) {
+	g := NewGame()
+	g.Setup = true
+	start := g.Pond.TotalPopulation()
+	for i := 0; i < 3; i++ {
+		if err := g.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.TickCounter != 0 || g.Pond.StepCount != 0 || g.Pond.TotalPopulation() != start {
+		t.Fatalf("setup ran the pond: tick %d, %d steps", g.TickCounter, g.Pond.StepCount)
+	}
+
+	// Counts edited during setup are the ones the run starts from
+	want := g.Pond.Molecules["E"] + 5*DefaultSetupStep
+	g.Pond.Molecules["E"] = adjustCount(g.Pond.Molecules["E"], 5, g.SetupStep)
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Pond.Molecules["E"]; g.TickCounter != 0 || got != want {
+		t.Fatalf("E = %d at tick %d during setup, want %d at tick 0", got, g.TickCounter, want)
+	}
+	g.Setup = false
+	if err := g.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if g.TickCounter == 0 {
+		t.Error("run did not start once setup ended")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// --- Snapshots & Checkpoints ---
+
+// Snapshot is the experiment config plus the point in the run it was taken at.
+type Snapshot struct {
+	Tick   int     `json:"tick"`
+	Config *Config `json:"config"`
+}
+
+// Snapshot captures the game's current state.
+func (g *Game) Snapshot() *Snapshot {
+	return &Snapshot{Tick: g.TickCounter, Config: g.Config()}
+}
+
+// SaveSnapshot writes the game's state to path. The file is written to a
+// temporary name first and renamed into place, so a crash mid-write never
+// leaves a truncated snapshot behind.
+func (g *Game) SaveSnapshot(path string) error {
+	data, err := json.MarshalIndent(g.Snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads and validates a snapshot file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	if snap.Config == nil {
+		return nil, fmt.Errorf("snapshot %s has no config", path)
+	}
+	if err := snap.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// NewGame restores a Game at the snapshot's tick. The random source is
+// reseeded from the seed and tick, so resumed runs are reproducible but do
+// not continue the original random stream.
+func (s *Snapshot) NewGame() *Game {
+	g := s.Config.NewGame()
+	g.TickCounter = s.Tick
+	g.Pond.rng = newCloneableRand(s.Config.Seed + int64(s.Tick))
+	return g
+}
+
+// checkpointPaths returns the two files checkpoints rotate between.
+func checkpointPaths(base string) [2]string {
+	return [2]string{base + ".0", base + ".1"}
+}
+
+// checkpoint saves a snapshot over the older of the two checkpoint slots, so
+// the most recent good checkpoint survives a crash during the write.
+func (g *Game) checkpoint() error {
+	paths := checkpointPaths(g.CheckpointPath)
+	return g.SaveSnapshot(paths[olderSlot(paths)])
+}
+
+// olderSlot returns the index of the slot to overwrite: a missing file first,
+// otherwise the one modified least recently.
+func olderSlot(paths [2]string) int {
+	first, err := os.Stat(paths[0])
+	if err != nil {
+		return 0
+	}
+	second, err := os.Stat(paths[1])
+	if err != nil {
+		return 1
+	}
+	if second.ModTime().Before(first.ModTime()) {
+		return 1
+	}
+	return 0
+}
+
+// LoadLatestCheckpoint loads whichever rotating checkpoint is valid and most
+// recent. An unreadable or corrupt slot is skipped in favour of the other.
+func LoadLatestCheckpoint(base string) (*Snapshot, error) {
+	var latest *Snapshot
+	var errs []error
+	for _, path := range checkpointPaths(base) {
+		snap, err := LoadSnapshot(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if latest == nil || snap.Tick > latest.Tick {
+			latest = snap
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no valid checkpoint for %s: %w", base, errors.Join(errs...))
+	}
+	return latest, nil
+}
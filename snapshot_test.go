@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCheckpointRotation(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(1))
+	g.CheckpointEvery = 2
+	g.CheckpointPath = filepath.Join(t.TempDir(), "run.json")
+	for i := 0; i < 7; i++ {
+		g.advance(100)
+		time.Sleep(10 * time.Millisecond) // Distinct modification times for the rotation
+	}
+
+	// Checkpoints were written at ticks 2, 4 and 6; the rotation keeps the last two
+	var ticks []int
+	for _, path := range checkpointPaths(g.CheckpointPath) {
+		snap, err := LoadSnapshot(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ticks = append(ticks, snap.Tick)
+	}
+	sort.Ints(ticks)
+	if want := []int{4, 6}; !reflect.DeepEqual(ticks, want) {
+		t.Errorf("checkpoint ticks = %v, want %v", ticks, want)
+	}
+
+	snap, err := LoadLatestCheckpoint(g.CheckpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Tick != 6 {
+		t.Errorf("latest checkpoint is tick %d, want 6", snap.Tick)
+	}
+	resumed := snap.NewGame()
+	if resumed.TickCounter != 6 {
+		t.Errorf("resumed at tick %d, want 6", resumed.TickCounter)
+	}
+}
+
+func TestLoadLatestCheckpointSkipsCorruptSlot(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(1))
+	g.CheckpointPath = filepath.Join(t.TempDir(), "run.json")
+	g.TickCounter = 3
+	if err := g.checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	paths := checkpointPaths(g.CheckpointPath)
+	if err := os.WriteFile(paths[1], []byte("{truncated"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := LoadLatestCheckpoint(g.CheckpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Tick != 3 {
+		t.Errorf("loaded tick %d, want 3", snap.Tick)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import "maps"
+
+// --- Observation Window Statistics ---
+
+// windowStats accumulates per-tick statistics since the pond's last
+// ResetStats, so a run's transient can be left out of them.
+type windowStats struct {
+	ticks       int            // Ticks observed
+	sums        map[string]int // Summed end-of-tick counts
+	extinctions map[string]int // Times each species dropped to zero
+	last        map[string]int // Counts at the previous observed tick
+}
+
+// observe adds the pond's end-of-tick counts to the window.
+func (s *windowStats) observe(counts map[string]int) {
+	if s.sums == nil {
+		s.sums, s.extinctions = map[string]int{}, map[string]int{}
+	}
+	s.ticks++
+	for name, count := range counts {
+		s.sums[name] += count
+		if s.last[name] > 0 && count == 0 {
+			s.extinctions[name]++
+		}
+	}
+	s.last = maps.Clone(counts)
+}
+
+// MeanCounts returns each species' mean end-of-tick count over the window:
+// since the last ResetStats, whose tick is the window's first, or since the
+// start of the run. It returns nil before the first tick has ended.
+func (p *Pond) MeanCounts() map[string]float64 {
+	if p.stats.ticks == 0 {
+		return nil
+	}
+	means := make(map[string]float64, len(p.stats.sums))
+	for name, sum := range p.stats.sums {
+		means[name] = float64(sum) / float64(p.stats.ticks)
+	}
+	return means
+}
+
+// Extinctions returns how often each species went extinct at the end of a
+// tick since the last ResetStats.
+func (p *Pond) Extinctions() map[string]int {
+	return maps.Clone(p.stats.extinctions)
+}
+
+// ResetStats starts a new observation window at the current tick: fire
+// counts and extinction counts restart from zero and the mean counts restart
+// from the current counts, so later statistics reflect only what happens
+// from now on. The molecules and reactions are untouched.
+func (p *Pond) ResetStats() {
+	for i := range p.FireCounts {
+		p.FireCounts[i] = 0
+	}
+	p.stats = windowStats{
+		ticks:       1,
+		sums:        maps.Clone(p.Molecules),
+		extinctions: map[string]int{},
+		last:        maps.Clone(p.Molecules),
+	}
+}
+
+// ResetStats resets the pond's statistics and the all-time high-water marks.
+func (g *Game) ResetStats() {
+	g.Pond.ResetStats()
+	g.HighWater = HighWater{}
+	g.HighWater.Observe(g.Pond.Molecules)
+	g.idle = quiescenceDetector{}
+	logf(LevelInfo, "tick %d: statistics reset", g.TickCounter)
+}
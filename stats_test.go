@@ -0,0 +1,67 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestResetStatsRestartsMeanFromCurrentCounts(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 100, "B": 0}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	if p.MeanCounts() != nil {
+		t.Fatal("mean counts before any tick")
+	}
+	for tick := 1; tick <= 5; tick++ {
+		p.runTick(10)
+	}
+	molecules := maps.Clone(p.Molecules)
+
+	p.ResetStats()
+	if !maps.Equal(p.Molecules, molecules) {
+		t.Fatalf("ResetStats changed the molecules to %v", p.Molecules)
+	}
+	if got := p.TotalFires(); got != 0 {
+		t.Errorf("%d fires after reset, want 0", got)
+	}
+	mean := p.MeanCounts()
+	if mean["A"] != 50 || mean["B"] != 50 {
+		t.Errorf("mean right after reset = %v, want the current counts A 50, B 50", mean)
+	}
+
+	// The window's next tick averages in with the reset tick only
+	p.runTick(10)
+	if mean := p.MeanCounts(); mean["A"] != 45 || mean["B"] != 55 {
+		t.Errorf("mean one tick after reset = %v, want A 45, B 55", mean)
+	}
+	if got := p.TotalFires(); got != 10 {
+		t.Errorf("%d fires since reset, want 10", got)
+	}
+}
+
+func TestResetStatsExtinctions(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 15, "B": 0}, Reaction{Reactants: []string{"A"}, Product: "B"})
+	p.runTick(10)
+	p.runTick(10) // A dies out
+	if got := p.Extinctions()["A"]; got != 1 {
+		t.Fatalf("A went extinct %d times, want 1", got)
+	}
+	p.ResetStats()
+	p.runTick(10)
+	if got := p.Extinctions()["A"]; got != 0 {
+		t.Errorf("A extinct %d times since reset, want 0: it was already gone", got)
+	}
+}
+
+func TestGameResetStatsAt(t *testing.T) {
+	g := NewGame()
+	g.ResetStatsAt = 10
+	g.RunTo(10)
+	mean := g.Pond.MeanCounts()
+	for name, count := range g.Pond.Molecules {
+		if mean[name] != float64(count) {
+			t.Errorf("mean %s = %v at the reset tick, want %d", name, mean[name], count)
+		}
+	}
+	if got := g.Pond.TotalFires(); got != 0 {
+		t.Errorf("%d fires at the reset tick, want 0", got)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Stoichiometric Matrix ---
+
+// StoichiometryMatrix returns the species x reactions stoichiometric matrix
+// with its row (species) and column (reaction) labels. Entry [i][j] is the net
+// change in species i when reaction j fires: negative for a species consumed,
+// positive for one produced, zero for catalysts and bystanders. Repeated
+// reactants and products count once per occurrence, so "2A -> B" has -2 for
+// A. A branching reaction gets one column per branch, labelled "R3a", "R3b",
+// and so on; other reactions are labelled "R1", "R2", ...
+func (p *Pond) StoichiometryMatrix() ([][]int, []string, []string) {
+	species := p.networkSpecies()
+	row := make(map[string]int, len(species))
+	for i, name := range species {
+		row[name] = i
+	}
+
+	var labels []string
+	var columns [][]int
+	addColumn := func(label string, reactants, products []string) {
+		col := make([]int, len(species))
+		for _, name := range reactants {
+			col[row[name]]--
+		}
+		for _, name := range products {
+			col[row[name]]++
+		}
+		labels = append(labels, label)
+		columns = append(columns, col)
+	}
+	for i, r := range p.Reactions {
+		if len(r.Branches) == 0 {
+			addColumn(fmt.Sprintf("R%d", i+1), r.Reactants, r.YieldedProducts())
+			continue
+		}
+		for k, b := range r.Branches {
+			addColumn(fmt.Sprintf("R%d%c", i+1, 'a'+k), r.Reactants, b.Products)
+		}
+	}
+
+	matrix := make([][]int, len(species))
+	for i := range matrix {
+		matrix[i] = make([]int, len(columns))
+		for j, col := range columns {
+			matrix[i][j] = col[i]
+		}
+	}
+	return matrix, species, labels
+}
+
+// networkSpecies returns every species in the pond or mentioned by a
+// reaction, sorted.
+func (p *Pond) networkSpecies() []string {
+	seen := make(map[string]bool, len(p.Molecules))
+	for name := range p.Molecules {
+		seen[name] = true
+	}
+	for _, r := range p.Reactions {
+		for _, name := range append(append(r.AllProducts(), r.Reactants...), r.AllCatalysts()...) {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatStoichiometryCSV renders the matrix as CSV with a header row of
+// reaction labels and a leading column of species names.
+func FormatStoichiometryCSV(matrix [][]int, species, reactions []string) string {
+	var b strings.Builder
+	b.WriteString("species," + strings.Join(reactions, ",") + "\n")
+	for i, name := range species {
+		b.WriteString(name)
+		for _, v := range matrix[i] {
+			fmt.Fprintf(&b, ",%d", v)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStoichiometryMatrix(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 1},
+		Reaction{Reactants: []string{"A", "A"}, Product: "B"},
+		Reaction{Reactants: []string{"B"}, Product: "C", Catalysts: []string{"E"}},
+		Reaction{Reactants: []string{"C"}, Branches: []Branch{
+			{Products: []string{"A"}, Probability: 1},
+			{Products: []string{"B", "B"}, Probability: 1},
+		}},
+	)
+	matrix, species, reactions := p.StoichiometryMatrix()
+
+	if want := []string{"A", "B", "C", "E"}; !reflect.DeepEqual(species, want) {
+		t.Errorf("species = %v, want %v", species, want)
+	}
+	if want := []string{"R1", "R2", "R3a", "R3b"}; !reflect.DeepEqual(reactions, want) {
+		t.Errorf("reactions = %v, want %v", reactions, want)
+	}
+	want := [][]int{
+		{-2, 0, 1, 0},  // A: consumed twice by R1, made by R3a
+		{1, -1, 0, 2},  // B
+		{0, 1, -1, -1}, // C
+		{0, 0, 0, 0},   // E only catalyzes
+	}
+	if !reflect.DeepEqual(matrix, want) {
+		t.Errorf("matrix = %v, want %v", matrix, want)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// --- Abundance Heat Strip ---
+
+// abundanceLevels maps each count to its brightness in [0,1] relative to the
+// most abundant species. When every count is zero all levels are zero.
+func abundanceLevels(counts []int) []float64 {
+	max := 0
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+
+	levels := make([]float64, len(counts))
+	if max == 0 {
+		return levels
+	}
+	for i, c := range counts {
+		if c > 0 {
+			levels[i] = float64(c) / float64(max)
+		}
+	}
+	return levels
+}
+
+// drawHeatStrip draws one segment per species in area, shaded from dark
+// (absent) to the species' full color (most abundant).
+func (g *Game) drawHeatStrip(screen *ebiten.Image, area image.Rectangle) {
+	names := g.Pond.SpeciesNames()
+	if len(names) == 0 {
+		return
+	}
+	counts := make([]int, len(names))
+	for i, name := range names {
+		counts[i] = g.Pond.Molecules[name]
+	}
+
+	segment := float32(area.Dx()) / float32(len(names))
+	for i, level := range abundanceLevels(counts) {
+		base := g.Theme.SpeciesColor(names[i])
+		shade := 0.1 + 0.9*level // Keep absent species faintly visible
+		clr := color.RGBA{uint8(float64(base.R) * shade), uint8(float64(base.G) * shade), uint8(float64(base.B) * shade), 255}
+		x := float32(area.Min.X) + float32(i)*segment
+		vector.FillRect(screen, x, float32(area.Min.Y), segment, float32(area.Dy()), clr, false)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAbundanceLevels(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   []float64
+	}{
+		{"all zero", []int{0, 0, 0}, []float64{0, 0, 0}},
+		{"relative to max", []int{0, 50, 100, 25}, []float64{0, 0.5, 1, 0.25}},
+		{"single species", []int{7}, []float64{1}},
+		{"negative counts are dark", []int{-5, 10}, []float64{0, 1}},
+		{"empty", nil, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := abundanceLevels(tt.counts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("abundanceLevels(%v) = %v, want %v", tt.counts, got, tt.want)
+			}
+		})
+	}
+}
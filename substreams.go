@@ -0,0 +1,28 @@
+package main
+
+// --- Per-Reaction Random Streams ---
+
+// reactionSeedOffset separates the reaction streams from the main stream of the same seed.
+const reactionSeedOffset = 0x7265616374 // "react"
+
+// reactionRand returns the random stream owned by reaction i. A reaction's
+// own stochastic decisions (its rate noise and branch choice) come from this
+// stream, so adding, removing or disabling one reaction never shifts the
+// draws of another.
+func (p *Pond) reactionRand(i int) *cloneableRand {
+	if i >= len(p.reactionRands) {
+		p.seedReactionRands()
+	}
+	return p.reactionRands[i]
+}
+
+// seedReactionRands gives every reaction that has no stream yet its own,
+// seeded from the pond's seed and the number of streams seeded before it.
+// A stream stays with its reaction when earlier ones are removed, and a
+// later reaction never receives a seed already used.
+func (p *Pond) seedReactionRands() {
+	for len(p.reactionRands) < len(p.Reactions) {
+		p.streams++
+		p.reactionRands = append(p.reactionRands, newCloneableRand(p.Seed+reactionSeedOffset*int64(p.streams)))
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// branchingPond has two independent branching reactions sharing no species.
+func branchingPond() *Pond {
+	split := func(from, left, right string) Reaction {
+		return Reaction{Reactants: []string{from}, Branches: []Branch{
+			{Products: []string{left}, Probability: 1},
+			{Products: []string{right}, Probability: 1},
+		}}
+	}
+	return testPond(1, map[string]int{"A": 1000, "X": 1000},
+		split("A", "B", "C"),
+		split("X", "Y", "Z"),
+	)
+}
+
+// branchesOf runs p and returns the branches taken by reaction idx, in order.
+func branchesOf(p *Pond, idx, steps int) []int {
+	var taken []int
+	p.AddFireObserver(func(reaction, branch int) {
+		if reaction == idx {
+			taken = append(taken, branch)
+		}
+	})
+	p.Run(steps)
+	return taken
+}
+
+func TestDisablingReactionKeepsOtherDraws(t *testing.T) {
+	baseline := branchesOf(branchingPond(), 1, 500)
+
+	p := branchingPond()
+	p.Reactions[0].Disabled = true
+	alone := branchesOf(p, 1, 500)
+
+	n := min(len(baseline), len(alone))
+	if n < 100 {
+		t.Fatalf("only %d fires of R2 to compare", n)
+	}
+	if !slices.Equal(baseline[:n], alone[:n]) {
+		t.Errorf("R2 branches changed when R1 was disabled:\n%v\nvs\n%v", baseline[:n], alone[:n])
+	}
+}
+
+func TestReactionStreamsSurviveRemoval(t *testing.T) {
+	kept, removed := branchingPond(), branchingPond()
+	removed.RemoveReaction(0)
+	for i := 0; i < 100; i++ {
+		if a, b := kept.reactionRand(1).Int63(), removed.reactionRand(0).Int63(); a != b {
+			t.Fatalf("draw %d: R2's stream changed after removing R1", i)
+		}
+	}
+
+	// A reaction added afterwards gets a fresh stream, not one already used
+	idx := removed.AddReaction(Reaction{Reactants: []string{"B"}, Product: "C"})
+	fresh := branchingPond()
+	if removed.reactionRand(idx).Int63() == fresh.reactionRand(1).Int63() {
+		t.Error("added reaction reuses the stream of R2")
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSuccessProbabilityHalf(t *testing.T) {
+	const steps, initial = 10000, 1000000
+	p := testPond(1, map[string]int{"A": initial, "B": 0},
+		Reaction{Reactants: []string{"A"}, Product: "B", SuccessProbability: 0.5})
+	for i := 0; i < steps; i++ {
+		p.Step()
+	}
+
+	fires := p.FireCounts[0]
+	if share := float64(fires) / steps; math.Abs(share-0.5) > 0.02 {
+		t.Errorf("fired %d of %d eligible selections (%.3f), want about half", fires, steps, share)
+	}
+	// Failed attempts leave the counts untouched
+	if p.Molecules["A"] != initial-fires || p.Molecules["B"] != fires {
+		t.Errorf("A = %d, B = %d after %d fires", p.Molecules["A"], p.Molecules["B"], fires)
+	}
+}
+
+func TestSuccessProbabilityBounds(t *testing.T) {
+	for _, prob := range []float64{0, 1} {
+		p := testPond(1, map[string]int{"A": 100, "B": 0},
+			Reaction{Reactants: []string{"A"}, Product: "B", SuccessProbability: prob})
+		for i := 0; i < 50; i++ {
+			p.Step()
+		}
+		if p.FireCounts[0] != 50 {
+			t.Errorf("success probability %v: %d of 50 fired, want every one", prob, p.FireCounts[0])
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import "image/color"
+
+// --- Color Themes ---
+
+// Theme is a palette for the display.
+type Theme struct {
+	Name string
+
+	Precursor    color.RGBA // D's name and count
+	PrecursorBar color.RGBA
+	Product      color.RGBA // E's name and count before emergence
+	ProductBar   color.RGBA
+	Dominant     color.RGBA // E and the emergence message once E is dominant
+	DominantCue  string     // Shown at the end of E's row once it is dominant, so the cue does not rest on hue alone
+
+	Header color.RGBA // Table headings
+	Dim    color.RGBA // Labels and secondary text
+
+	// Saturation and value of the hashed colors of the other species
+	Saturation float64
+	Value      float64
+}
+
+// DefaultTheme is the name of the theme used when none, or an unknown one, is chosen.
+const DefaultTheme = "default"
+
+// themeNames lists the themes in the order the theme key cycles through them.
+var themeNames = []string{DefaultTheme, "colorblind", "high-contrast"}
+
+// themes holds every selectable theme by name. The colorblind theme uses the
+// Okabe-Ito palette, swapping the default red/green emergence cue for
+// orange/blue.
+var themes = map[string]Theme{
+	DefaultTheme: {
+		Name:         DefaultTheme,
+		Precursor:    color.RGBA{255, 255, 0, 255}, // Yellow for the precursor
+		PrecursorBar: color.RGBA{255, 255, 0, 100},
+		Product:      color.RGBA{255, 100, 50, 255}, // Red/Orange for the autocatalytic product
+		ProductBar:   color.RGBA{255, 0, 0, 100},
+		Dominant:     color.RGBA{0, 255, 0, 255}, // Green when dominant
+		Header:       color.RGBA{100, 200, 255, 255},
+		Dim:          color.RGBA{180, 180, 180, 255},
+		Saturation:   0.55,
+		Value:        0.95,
+	},
+	"colorblind": {
+		Name:         "colorblind",
+		Precursor:    color.RGBA{240, 228, 66, 255},
+		PrecursorBar: color.RGBA{240, 228, 66, 100},
+		Product:      color.RGBA{230, 159, 0, 255},
+		ProductBar:   color.RGBA{213, 94, 0, 120},
+		Dominant:     color.RGBA{86, 180, 233, 255},
+		DominantCue:  "DOMINANT",
+		Header:       color.RGBA{204, 121, 167, 255},
+		Dim:          color.RGBA{180, 180, 180, 255},
+		Saturation:   0.45,
+		Value:        0.9,
+	},
+	"high-contrast": {
+		Name:         "high-contrast",
+		Precursor:    color.RGBA{255, 255, 0, 255},
+		PrecursorBar: color.RGBA{255, 255, 0, 200},
+		Product:      color.RGBA{255, 128, 0, 255},
+		ProductBar:   color.RGBA{255, 128, 0, 200},
+		Dominant:     color.RGBA{0, 255, 255, 255},
+		DominantCue:  "DOMINANT",
+		Header:       color.RGBA{255, 255, 255, 255},
+		Dim:          color.RGBA{255, 255, 255, 255},
+		Saturation:   1,
+		Value:        1,
+	},
+}
+
+// ThemeByName returns the named theme. An unknown name gives the default
+// theme and false.
+func ThemeByName(name string) (Theme, bool) {
+	t, ok := themes[name]
+	if !ok {
+		return themes[DefaultTheme], false
+	}
+	return t, true
+}
+
+// nextTheme returns the theme after t in themeNames, wrapping around.
+func nextTheme(t Theme) Theme {
+	for i, name := range themeNames {
+		if name == t.Name {
+			next, _ := ThemeByName(themeNames[(i+1)%len(themeNames)])
+			return next
+		}
+	}
+	next, _ := ThemeByName(DefaultTheme)
+	return next
+}
+
+// SpeciesColor returns the theme's color for a species: the highlight color
+// of D and E, or the species' hashed color.
+func (t Theme) SpeciesColor(name string) color.RGBA {
+	switch name {
+	case "D":
+		return t.Precursor
+	case "E":
+		return t.Product
+	}
+	return hashedColor(name, t.Saturation, t.Value)
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestThemeByName(t *testing.T) {
+	for _, name := range themeNames {
+		theme, ok := ThemeByName(name)
+		if !ok || theme.Name != name {
+			t.Errorf("ThemeByName(%q) = %q, %t; want %q, true", name, theme.Name, ok, name)
+		}
+	}
+	for _, name := range []string{"", "solarized", "Colorblind"} {
+		if theme, ok := ThemeByName(name); ok || theme.Name != DefaultTheme {
+			t.Errorf("ThemeByName(%q) = %q, %t; want %q, false", name, theme.Name, ok, DefaultTheme)
+		}
+	}
+}
+
+func TestColorblindThemeAvoidsRedGreen(t *testing.T) {
+	theme, _ := ThemeByName("colorblind")
+	if theme.DominantCue == "" {
+		t.Error("colorblind theme has no text cue for dominance")
+	}
+	if theme.Dominant == theme.Product {
+		t.Errorf("dominant and product share color %v", theme.Dominant)
+	}
+	// The emergence cue must not be the default's pure green
+	if def, _ := ThemeByName(DefaultTheme); theme.Dominant == def.Dominant {
+		t.Errorf("colorblind dominant color %v is the default's", theme.Dominant)
+	}
+}
+
+func TestNextThemeCycles(t *testing.T) {
+	theme, _ := ThemeByName(DefaultTheme)
+	for i := 1; i <= len(themeNames); i++ {
+		theme = nextTheme(theme)
+		if want := themeNames[i%len(themeNames)]; theme.Name != want {
+			t.Errorf("step %d: nextTheme gave %q, want %q", i, theme.Name, want)
+		}
+	}
+}
+
+func TestSpeciesColor(t *testing.T) {
+	theme, _ := ThemeByName("high-contrast")
+	if got := theme.SpeciesColor("D"); got != theme.Precursor {
+		t.Errorf("SpeciesColor(D) = %v, want the precursor color %v", got, theme.Precursor)
+	}
+	if got := theme.SpeciesColor("E"); got != theme.Product {
+		t.Errorf("SpeciesColor(E) = %v, want the product color %v", got, theme.Product)
+	}
+	if a, b := theme.SpeciesColor("X"), theme.SpeciesColor("X"); a != b {
+		t.Errorf("SpeciesColor(X) = %v then %v", a, b)
+	}
+}
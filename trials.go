@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// --- Multi-Trial Runner ---
+
+// TrialResult holds the outcome of one independent trial.
+type TrialResult struct {
+	Trial  int
+	Seed   int64
+	Counts map[string]int
+}
+
+// DefaultConfig returns the built-in chemistry and parameters with seed 0.
+func DefaultConfig() *Config {
+	return newGameWithPond(NewPondWithSeed(0)).Config()
+}
+
+// RunTrials runs independent copies of the experiment concurrently, each for
+// the given number of steps. Trial i is seeded with cfg.Seed+i and owns its
+// random source, so results depend only on the config and never on scheduling.
+func RunTrials(cfg *Config, trials, steps int) []TrialResult {
+	results := make([]TrialResult, trials)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i := 0; i < trials; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			trialCfg := *cfg
+			trialCfg.Seed = cfg.Seed + int64(i)
+			g := trialCfg.NewGame()
+			for done := 0; done < steps && g.StepsPerTick > 0; {
+				n := min(g.StepsPerTick, steps-done)
+				g.advance(n)
+				done += n
+			}
+
+			results[i] = TrialResult{Trial: i, Seed: trialCfg.Seed, Counts: g.Pond.Molecules}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// FormatTrials renders per-trial final counts followed by the mean of each species.
+func FormatTrials(results []TrialResult) string {
+	var names []string
+	if len(results) > 0 {
+		names = (&Pond{Molecules: results[0].Counts}).SpeciesNames()
+	}
+
+	var b strings.Builder
+	sums := make(map[string]int)
+	for _, res := range results {
+		fmt.Fprintf(&b, "trial %d (seed %d):", res.Trial, res.Seed)
+		for _, name := range names {
+			fmt.Fprintf(&b, " %s=%d", name, res.Counts[name])
+			sums[name] += res.Counts[name]
+		}
+		b.WriteString("\n")
+	}
+	if len(results) > 0 {
+		b.WriteString("mean:")
+		for _, name := range names {
+			fmt.Fprintf(&b, " %s=%.2f", name, float64(sums[name])/float64(len(results)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestRunTrialsIsReproducible(t *testing.T) {
+	cfg := DefaultConfig()
+	first := FormatTrials(RunTrials(cfg, 8, 20000))
+	second := FormatTrials(RunTrials(cfg, 8, 20000))
+	if first != second {
+		t.Errorf("repeated trials differ:\n%s\nvs\n%s", first, second)
+	}
+}
+
+func TestRunTrialsSeedsByIndex(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Seed = 100
+	for i, res := range RunTrials(cfg, 4, 1000) {
+		if res.Trial != i || res.Seed != 100+int64(i) {
+			t.Errorf("result %d is trial %d with seed %d, want trial %d with seed %d", i, res.Trial, res.Seed, i, 100+i)
+		}
+	}
+}
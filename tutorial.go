@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// --- Tutorial Overlay ---
+
+// TutorialLines explains the pond's chemistry from its metadata: one line per
+// species with a note and one per reaction with a description. Species and
+// reactions without metadata are listed without explanation.
+func (p *Pond) TutorialLines() []string {
+	lines := []string{"Molecules:"}
+	for _, name := range p.SpeciesNames() {
+		line := "  " + name
+		if note := p.Notes[name]; note != "" {
+			line += " - " + note
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", "Reactions:")
+	for i, r := range p.Reactions {
+		lines = append(lines, fmt.Sprintf("  R%d  %s", i+1, r))
+		if r.Description != "" {
+			lines = append(lines, "      "+r.Description)
+		}
+	}
+	return lines
+}
+
+// Placement of the tutorial overlay, over the table and graph.
+const (
+	tutorialX        = 40
+	tutorialY        = 80
+	tutorialWidth    = ScreenWidth - 2*tutorialX
+	tutorialLineStep = 15
+)
+
+// drawTutorial draws the tutorial text in a panel over the rest of the screen.
+func (g *Game) drawTutorial(screen *ebiten.Image) {
+	lines := append([]string{"What am I looking at? (F1 to close)", ""}, g.Pond.TutorialLines()...)
+	height := float32(20 + tutorialLineStep*len(lines))
+	vector.FillRect(screen, tutorialX, tutorialY, tutorialWidth, height, color.RGBA{10, 10, 30, 240}, false)
+	vector.StrokeRect(screen, tutorialX, tutorialY, tutorialWidth, height, 1, g.Theme.Header, false)
+
+	y := tutorialY + 5
+	for i, line := range lines {
+		y += tutorialLineStep
+		clr := color.Color(color.White)
+		if i == 0 {
+			clr = g.Theme.Header
+		}
+		text.Draw(screen, line, basicfont.Face7x13, tutorialX+10, y, clr)
+	}
+}
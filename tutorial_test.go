@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTutorialLinesDefaultPond(t *testing.T) {
+	want := []string{
+		"Molecules:",
+		"  A - food molecule",
+		"  B - food molecule",
+		"  C - food molecule",
+		"  D - precursor complex, built from food",
+		"  E - the self-reproducing replicator (catalyzes its own formation)",
+		"",
+		"Reactions:",
+		"  R1  A + B -> D",
+		"      Basic synthesis: food combines into the precursor D",
+		"  R2  D + C -> E",
+		"      Initial complex formation: the slow, uncatalyzed route to E",
+		"  R3  D + A -> E [cat: E]",
+		"      Autocatalysis: E speeds up its own formation",
+		"  R4  E -> A",
+		"      Degradation/recycling: E breaks down, returning food",
+	}
+	if got := NewPond().TutorialLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TutorialLines() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTutorialLinesWithoutMetadata(t *testing.T) {
+	p := testPond(1, map[string]int{"X": 1}, Reaction{Reactants: []string{"X"}, Product: "Y"})
+	want := []string{"Molecules:", "  X", "", "Reactions:", "  R1  X -> Y"}
+	if got := p.TutorialLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TutorialLines() = %q, want %q", got, want)
+	}
+}
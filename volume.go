@@ -0,0 +1,22 @@
+package main
+
+// --- Volume & Concentrations ---
+
+// volume returns the pond volume, treating an unset volume as 1.
+func (p *Pond) volume() float64 {
+	if p.Volume <= 0 {
+		return 1
+	}
+	return p.Volume
+}
+
+// Concentration returns the count of a species divided by the pond volume.
+func (p *Pond) Concentration(species string) float64 {
+	return float64(p.Molecules[species]) / p.volume()
+}
+
+// CountForConcentration converts a concentration to a whole molecule count,
+// rounded with the pond's rounding mode.
+func (p *Pond) CountForConcentration(concentration float64) int {
+	return p.round(concentration * p.volume())
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVolumeDilutesBimolecularPropensity(t *testing.T) {
+	bimolecular := Reaction{Reactants: []string{"A", "B"}, Product: "D"}
+	unimolecular := Reaction{Reactants: []string{"E"}, Product: "A"}
+	counts := map[string]int{"A": 100, "B": 50, "E": 30}
+
+	small := testPond(1, counts, bimolecular, unimolecular)
+	small.Volume = 1
+	large := testPond(1, counts, bimolecular, unimolecular)
+	large.Volume = 2
+
+	if got, want := large.Propensity(0), small.Propensity(0)/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("bimolecular propensity at double volume = %v, want %v", got, want)
+	}
+	if large.Propensity(1) != small.Propensity(1) {
+		t.Errorf("unimolecular propensity changed with volume: %v vs %v", large.Propensity(1), small.Propensity(1))
+	}
+}
+
+func TestConcentrationConversions(t *testing.T) {
+	p := testPond(1, map[string]int{"A": 250})
+	p.Volume = 2.5
+	if c := p.Concentration("A"); c != 100 {
+		t.Errorf("Concentration(A) = %v, want 100", c)
+	}
+	if n := p.CountForConcentration(40); n != 100 {
+		t.Errorf("CountForConcentration(40) = %d, want 100", n)
+	}
+
+	p.Volume = 0 // Unset volume counts as 1
+	if c := p.Concentration("A"); c != 250 {
+		t.Errorf("Concentration(A) without a volume = %v, want 250", c)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+func TestNewPondFromCounts(t *testing.T) {
+	counts := map[string]int{"A": 120, "B": 80, "C": 310, "D": 4, "E": 2750}
+	reactions := NewPondWithSeed(1).Reactions
+
+	p := NewPondFromCounts(counts, reactions)
+	if !maps.Equal(p.Molecules, counts) {
+		t.Errorf("counts = %v, want %v", p.Molecules, counts)
+	}
+	if !reflect.DeepEqual(p.Reactions, reactions) {
+		t.Errorf("reactions = %v, want %v", p.Reactions, reactions)
+	}
+
+	// Both arguments are copied
+	counts["E"] = 0
+	reactions[0].Rate = 9
+	if p.Molecules["E"] != 2750 || p.Reactions[0].Rate != 0 {
+		t.Error("pond shares its counts or reactions with the caller")
+	}
+	p.Step() // Must be ready to run
+}
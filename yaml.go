@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- YAML Configs ---
+
+// A YAML config is converted to JSON and then loaded exactly like a JSON
+// one, so both formats share the same schema and validation.
+
+// isYAMLPath reports whether a config path names a YAML file.
+func isYAMLPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// LoadPondYAML loads a YAML config and builds its pond.
+func LoadPondYAML(path string) (*Pond, error) {
+	if !isYAMLPath(path) {
+		return nil, fmt.Errorf("config %s: not a .yaml or .yml file", path)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewPond(), nil
+}
+
+// yamlToJSON converts a YAML document to the equivalent JSON. An empty
+// document becomes an empty object.
+func yamlToJSON(data []byte) ([]byte, error) {
+	v := any(map[string]any{})
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// jsonToYAML renders a JSON document as block-style YAML. Numbers are kept
+// as written, so int64 values such as seeds survive the trip exactly.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlNumbers(v)); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// yamlNumbers replaces the json.Numbers in a decoded document with YAML
+// scalars holding the same text, so no number passes through a float64.
+func yamlNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = yamlNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = yamlNumbers(item)
+		}
+	case json.Number:
+		tag := "!!float"
+		if _, err := v.Int64(); err == nil {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	}
+	return v
+}
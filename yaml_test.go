@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const equivalentJSON = `{
+  "seed": 7,
+  "stepsPerTick": 50,
+  "emergenceThreshold": 30,
+  "volume": 2,
+  "molecules": {"A": 500, "B": 500, "C": 500, "D": 0, "E": 1},
+  "reactions": [
+    "A + B -> D",
+    {"reactants": ["D", "C"], "product": "E", "rate": 0.5},
+    {"reactants": ["D", "A"], "product": "E", "catalysts": ["E"], "rate": 2},
+    {"reactants": ["E"], "product": "A", "description": "decay: back to food"}
+  ],
+  "notes": {"E": "the replicator"},
+  "maxCount": {"E": 800}
+}`
+
+const equivalentYAML = `# The same experiment as equivalentJSON
+seed: 7
+stepsPerTick: 50
+emergenceThreshold: 30
+volume: 2.0
+molecules: {A: 500, B: 500, C: 500, D: 0, E: 1}
+reactions:
+- A + B -> D
+- reactants: [D, C]
+  product: E
+  rate: 0.5
+- reactants:
+  - D
+  - A
+  product: E
+  catalysts: [E]
+  rate: 2
+- reactants: [E]
+  product: 'A'
+  description: "decay: back to food"
+notes:
+  E: the replicator   # Comments may trail values
+maxCount:
+  E: 800
+`
+
+func TestYAMLAndJSONConfigsMatch(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath, yamlPath := filepath.Join(dir, "pond.json"), filepath.Join(dir, "pond.yaml")
+	if err := os.WriteFile(jsonPath, []byte(equivalentJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(yamlPath, []byte(equivalentYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromJSON, err := LoadConfig(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Fatalf("YAML config = %+v\nJSON config = %+v", fromYAML, fromJSON)
+	}
+
+	yamlPond, err := LoadPondYAML(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonPond := fromJSON.NewPond()
+	yamlPond.Run(5000)
+	jsonPond.Run(5000)
+	if !maps.Equal(yamlPond.Molecules, jsonPond.Molecules) || !reflect.DeepEqual(yamlPond.FireCounts, jsonPond.FireCounts) {
+		t.Errorf("after 5000 steps YAML pond %v, JSON pond %v", yamlPond.Molecules, jsonPond.Molecules)
+	}
+}
+
+func TestLoadPondYAMLRejectsOtherExtensions(t *testing.T) {
+	if _, err := LoadPondYAML("pond.json"); err == nil {
+		t.Error("LoadPondYAML accepted a .json path")
+	}
+}
+
+func TestYAMLToJSONErrors(t *testing.T) {
+	for _, doc := range []string{
+		"a: 1\na: 2\n",        // Duplicate key
+		"a: 1\n\tb: 2\n",      // Tab indentation
+		"a: [1, 2\n",          // Unterminated flow sequence
+		"a: 1\n    b: 2\n",    // Unexpected indentation
+		"a: \"unterminated\n", // Unterminated quote
+	} {
+		if v, err := yamlToJSON([]byte(doc)); err == nil {
+			t.Errorf("yamlToJSON(%q) = %s, want an error", doc, v)
+		}
+	}
+}
+
+func TestJSONToYAMLRoundTrip(t *testing.T) {
+	docs := []string{
+		equivalentJSON,
+		`{"empty": {}, "none": [], "null": null, "nested": [[1, 2], [], [{"a": "b"}]]}`,
+		`{"tricky": ["true", "42", "1e3", "null", "~", "- dash", "a: b", "#hash", " padded ", "it's", "", "x,y"]}`,
+		`{"numbers": [0, -1, 2.5, 1e-9, 12345678901, 1760000000123456789]}`,
+		`[{"a": 1, "b": [{"c": 2}]}, "x"]`,
+	}
+	for _, doc := range docs {
+		yamlDoc, err := jsonToYAML([]byte(doc))
+		if err != nil {
+			t.Fatalf("jsonToYAML(%s): %v", doc, err)
+		}
+		back, err := yamlToJSON(yamlDoc)
+		if err != nil {
+			t.Fatalf("yamlToJSON of\n%s: %v", yamlDoc, err)
+		}
+		var want, got any
+		if err := json.Unmarshal([]byte(doc), &want); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(back, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip of %s through\n%s gave %s", doc, yamlDoc, back)
+		}
+	}
+}
+
+func TestSaveConfigYAML(t *testing.T) {
+	g := newGameWithPond(NewPondWithSeed(3))
+	g.Pond.Notes = map[string]string{"E": "replicator: self-copying"}
+	path := filepath.Join(t.TempDir(), "saved.yml")
+	if err := g.SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cfg, g.Config(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded YAML config = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestSaveConfigYAMLKeepsLargeSeed(t *testing.T) {
+	const seed = 1760000000123456789 // Above 2^53, so a float64 would round it
+	g := newGameWithPond(NewPondWithSeed(seed))
+	path := filepath.Join(t.TempDir(), "saved.yaml")
+	if err := g.SaveConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Seed != seed {
+		t.Errorf("reloaded seed = %d, want %d", cfg.Seed, seed)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProductYieldPerFire(t *testing.T) {
+	p := testPond(1, map[string]int{"T": 5, "P": 0, "W": 0},
+		Reaction{Reactants: []string{"T"}, Product: "P", ByProducts: []string{"W"}, ProductYield: 10})
+	for fires := 1; fires <= 5; fires++ {
+		p.Step()
+		if p.FireCounts[0] != fires {
+			t.Fatalf("step %d: %d fires", fires, p.FireCounts[0])
+		}
+		if p.Molecules["P"] != 10*fires || p.Molecules["W"] != fires {
+			t.Errorf("after %d fires P = %d, W = %d; want %d, %d", fires, p.Molecules["P"], p.Molecules["W"], 10*fires, fires)
+		}
+	}
+}
+
+func TestYieldedProducts(t *testing.T) {
+	tests := []struct {
+		yield int
+		want  []string
+	}{
+		{0, []string{"P", "W"}}, // Unset means 1
+		{1, []string{"P", "W"}},
+		{3, []string{"P", "P", "P", "W"}},
+	}
+	for _, tt := range tests {
+		r := Reaction{Reactants: []string{"T"}, Product: "P", ByProducts: []string{"W"}, ProductYield: tt.yield}
+		if got := r.YieldedProducts(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("yield %d: YieldedProducts() = %v, want %v", tt.yield, got, tt.want)
+		}
+	}
+}